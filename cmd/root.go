@@ -18,8 +18,15 @@ limitations under the License.
 package cmd
 
 import (
+	"bufio"
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -30,40 +37,152 @@ import (
 
 var CfgFile string
 var ReCreate bool
+var Migrate bool
+var Apply bool
+var Rate float64
+var QPS float64
 
 // RootCmd represents the base command when called without any subcommands.
 var RootCmd = &cobra.Command{
 	Use:   "populator",
 	Short: "Populate given tables' w/ seed data",
 	Long:  "Populate given tables' w/ seed data",
-	Run: func(_ *cobra.Command, _ []string) {
-		if err := populate(); err != nil {
-			fmt.Println(err)
-		}
+	RunE: func(_ *cobra.Command, _ []string) error {
+		return populate()
 	},
+	// errors are printed by Execute
+	SilenceErrors: true,
+	SilenceUsage:  true,
 }
 
 func populate() error {
+	if err := database.ValidateProgress(database.Progress, database.ProgressInterval); err != nil {
+		return err
+	}
+
+	// bursts up to a second worth are allowed
+	if Rate > 0 {
+		database.RowLimiter = database.NewLimiter(Rate, max(Rate, 1))
+	}
+
+	if QPS > 0 {
+		database.QueryLimiter = database.NewLimiter(QPS, max(QPS, 1))
+	}
+
+	// the first signal stops populating gracefully, then the next one kills the process
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
 	db := database.DB()
 	cfg := config.Instance
 
+	var errs []error
+
+	reports := make(map[string]*database.Report, len(cfg.Tables))
+
 	for _, table := range cfg.Tables {
-		if ReCreate {
-			if err := db.DropTable(table); err != nil {
+		err := populateTable(ctx, db, table, reports)
+		if ctx.Err() != nil {
+			summarize(cfg.Tables, reports)
+			return errors.New("populating is interrupted")
+		}
+
+		if err != nil {
+			if table.OnError != config.OnErrorSkip {
 				return err
 			}
+
+			errs = append(errs, err)
 		}
+	}
 
-		if err := db.CreateTable(table); err != nil {
+	return errors.Join(errs...)
+}
+
+func populateTable(ctx context.Context, db database.DBClient, table *config.Table, reports map[string]*database.Report) error {
+	if ReCreate {
+		if err := db.DropTable(ctx, table); err != nil {
 			return err
 		}
-
-		if err := db.Populate(table); err != nil {
+	} else if Migrate {
+		if err := migrate(ctx, db, table); err != nil {
 			return err
 		}
 	}
 
-	return nil
+	if err := db.CreateTable(ctx, table); err != nil {
+		return err
+	}
+
+	report, err := db.Populate(ctx, table)
+	reports[table.Name] = report
+	fmt.Printf("table %s: %d of %d records are inserted w/ %d retries\n", table.Name, report.Inserted, table.Record, report.Retries)
+
+	return err
+}
+
+// summarize prints what was completed before populating is interrupted.
+func summarize(tables []*config.Table, reports map[string]*database.Report) {
+	fmt.Println("populating is interrupted, completed work is:")
+
+	for _, table := range tables {
+		report, ok := reports[table.Name]
+
+		switch {
+		case !ok:
+			fmt.Printf("    table %s: not started\n", table.Name)
+		case report.Inserted >= table.Record:
+			fmt.Printf("    table %s: completed w/ %d records\n", table.Name, report.Inserted)
+		default:
+			fmt.Printf("    table %s: %d of %d records are inserted\n", table.Name, report.Inserted, table.Record)
+		}
+	}
+}
+
+// migrate alters the existing table to follow the config, after showing the differences.
+func migrate(ctx context.Context, db database.DBClient, table *config.Table) error {
+	specs, err := db.DiffTable(ctx, table)
+	if err != nil {
+		return err
+	}
+
+	if len(specs) == 0 {
+		return nil
+	}
+
+	fmt.Printf("table %s differs from config:\n", table.Name)
+
+	for _, spec := range specs {
+		fmt.Println("    " + spec)
+	}
+
+	if !Apply && !confirm("apply these changes? [y/N]: ") {
+		fmt.Printf("migration of table %s is skipped\n", table.Name)
+		return nil
+	}
+
+	return db.AlterTable(ctx, table, specs)
+}
+
+func confirm(prompt string) bool {
+	fmt.Print(prompt)
+
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -108,6 +227,12 @@ func InitConfig() {
 	}
 
 	config.Instance.CompleteWithDefault()
+
+	// valuesFile is resolved relative to the config file.
+	if err := config.Instance.LoadValuePools(filepath.Dir(viper.ConfigFileUsed())); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
 }
 
 // LoadConfig assigns the configuration input to config.Instance.
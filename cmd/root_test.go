@@ -20,7 +20,10 @@ package cmd_test
 import (
 	"bytes"
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
@@ -35,6 +38,8 @@ var (
 	nilColumns  []*config.Column
 	nilIndexes  []*config.Index
 	nilValues   []interface{}
+
+	outOfRangeEntropy = 1.5
 )
 
 //nolint:funlen
@@ -758,6 +763,244 @@ func Test_LoadConfig_Tables(t *testing.T) {
 			},
 			err: nil,
 		},
+
+		{
+			name: "emoji alphabet on utf8mb3 table in tables part",
+			yaml: []byte(`
+                database:
+                  driver: mysql
+                  host: 127.0.0.1
+                  port: 3306
+                  user: root
+                  password: root
+                  name: testdb
+                tables:
+                - name: table_a
+                  columns:
+                    - name: col_1
+                      type: varchar
+                      order: 20
+                      alphabet: emoji
+                  charset: utf8mb3
+                  record: 100000
+            `),
+			config: []*config.Table{
+				{
+					Name: "table_a",
+					Columns: []*config.Column{
+						{
+							Name:     "col_1",
+							Type:     "varchar",
+							Order:    20,
+							Values:   nilValues,
+							Alphabet: "emoji",
+						},
+					},
+					Indexes: nilIndexes,
+					Charset: "utf8mb3",
+					Record:  100000,
+				},
+			},
+			err: errors.New("alphabet emoji of column col_1 cannot be stored in charset utf8mb3"),
+		},
+
+		{
+			name: "too long index key in tables part",
+			yaml: []byte(`
+                database:
+                  driver: mysql
+                  host: 127.0.0.1
+                  port: 3306
+                  user: root
+                  password: root
+                  name: testdb
+                tables:
+                - name: table_a
+                  columns:
+                    - name: col_1
+                      type: varchar
+                      order: 1000
+                  indexes:
+                    - columns:
+                        - col_1
+                  charset: utf8mb4
+                  record: 100000
+            `),
+			config: []*config.Table{
+				{
+					Name: "table_a",
+					Columns: []*config.Column{
+						{
+							Name:   "col_1",
+							Type:   "varchar",
+							Order:  1000,
+							Values: nilValues,
+						},
+					},
+					Indexes: []*config.Index{
+						{
+							Columns: []string{
+								"col_1",
+							},
+						},
+					},
+					Charset: "utf8mb4",
+					Record:  100000,
+				},
+			},
+			err: errors.New("index key on col_1 is 4000 bytes in charset utf8mb4, exceeds 3072 bytes"),
+		},
+
+		{
+			name: "unsupported row format of engine in tables part",
+			yaml: []byte(`
+                database:
+                  driver: mysql
+                  host: 127.0.0.1
+                  port: 3306
+                  user: root
+                  password: root
+                  name: testdb
+                tables:
+                - name: table_a
+                  columns:
+                    - name: col_1
+                      type: int
+                  options:
+                    engine: myisam
+                    rowFormat: compressed
+                  charset: utf8mb4
+                  record: 100000
+            `),
+			config: []*config.Table{
+				{
+					Name: "table_a",
+					Columns: []*config.Column{
+						{
+							Name:   "col_1",
+							Type:   "int",
+							Values: nilValues,
+						},
+					},
+					Indexes: nilIndexes,
+					Options: &config.TableOptions{
+						Engine:    "myisam",
+						RowFormat: "compressed",
+					},
+					Charset: "utf8mb4",
+					Record:  100000,
+				},
+			},
+			err: errors.New("row format COMPRESSED is not supported by MyISAM"),
+		},
+
+		{
+			name: "too long table name in tables part",
+			yaml: []byte(`
+                database:
+                  driver: mysql
+                  host: 127.0.0.1
+                  port: 3306
+                  user: root
+                  password: root
+                  name: testdb
+                tables:
+                - name: table_aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa
+                  columns:
+                    - name: col_1
+                      type: int
+                  charset: utf8mb4
+                  record: 100000
+            `),
+			config: []*config.Table{
+				{
+					Name: "table_aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+					Columns: []*config.Column{
+						{
+							Name:   "col_1",
+							Type:   "int",
+							Values: nilValues,
+						},
+					},
+					Charset: "utf8mb4",
+					Record:  100000,
+				},
+			},
+			err: errors.New("table name table_aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa exceeds 64 characters"),
+		},
+
+		{
+			name: "invalid onError in tables part",
+			yaml: []byte(`
+                database:
+                  driver: mysql
+                  host: 127.0.0.1
+                  port: 3306
+                  user: root
+                  password: root
+                  name: testdb
+                tables:
+                - name: table_a
+                  columns:
+                    - name: col_1
+                      type: int
+                  charset: utf8mb4
+                  record: 100000
+                  onError: ignore
+            `),
+			config: []*config.Table{
+				{
+					Name: "table_a",
+					Columns: []*config.Column{
+						{
+							Name:   "col_1",
+							Type:   "int",
+							Values: nilValues,
+						},
+					},
+					Charset: "utf8mb4",
+					Record:  100000,
+					OnError: "ignore",
+				},
+			},
+			err: errors.New(`onError "ignore" is invalid, it must be one of abort, skip and retry`),
+		},
+
+		{
+			name: "negative concurrency",
+			yaml: []byte(`
+                database:
+                  driver: mysql
+                  host: 127.0.0.1
+                  port: 3306
+                  user: root
+                  password: root
+                  name: testdb
+                concurrency: -1
+                tables:
+                - name: table_a
+                  columns:
+                    - name: col_1
+                      type: int
+                  charset: utf8mb4
+                  record: 100000
+            `),
+			config: []*config.Table{
+				{
+					Name: "table_a",
+					Columns: []*config.Column{
+						{
+							Name:   "col_1",
+							Type:   "int",
+							Values: nilValues,
+						},
+					},
+					Charset: "utf8mb4",
+					Record:  100000,
+				},
+			},
+			err: errors.New("concurrency must be positive"),
+		},
 	}
 
 	//nolint:dupl
@@ -1465,41 +1708,9 @@ func Test_LoadConfig_Columns(t *testing.T) {
 			},
 			err: nil,
 		},
-	}
-
-	//nolint:dupl
-	for _, c := range cases {
-		if err := viper.ReadConfig(bytes.NewBuffer(c.yaml)); err != nil {
-			t.Errorf("case: %s is failed, err: %s\n", c.name, err)
-		}
-
-		err := cmd.LoadConfig()
-		if !assert.Equal(t, c.err, err) {
-			t.Errorf("case: %s is failed, expected: %s, actual: %s\n", c.name, c.err, err)
-		}
-
-		cfg := config.Instance
-		if !assert.Equal(t, c.config, cfg.Tables) {
-			t.Errorf("case: %s is failed, expected: %+v, actual: %+v\n", c.name, c.config, cfg.Tables)
-		}
-
-		// reset global variable
-		config.Instance = nil
-	}
-}
-
-//nolint:funlen
-func Test_LoadConfig_Indexes(t *testing.T) {
-	viper.SetConfigType("yaml")
 
-	cases := []struct {
-		name   string
-		yaml   []byte
-		config interface{}
-		err    error
-	}{
 		{
-			name: "missing a name of index in indexes part",
+			name: "valuesFile of column in columns part",
 			yaml: []byte(`
                 database:
                   driver: mysql
@@ -1512,14 +1723,9 @@ func Test_LoadConfig_Indexes(t *testing.T) {
                 - name: table_a
                   columns:
                     - name: col_1
-                      type: float
-                      order: 5
-                      precision: 2
-                  indexes:
-                    - primary: false
-                      uniq: false
-                      columns:
-                        - col_1
+                      type: varchar
+                      order: 20
+                      valuesFile: ./values/products.csv
                   charset: utf8mb4
                   record: 100000
             `),
@@ -1528,28 +1734,14 @@ func Test_LoadConfig_Indexes(t *testing.T) {
 					Name: "table_a",
 					Columns: []*config.Column{
 						{
-							Name:          "col_1",
-							Type:          "float",
-							Order:         5,
-							Precision:     2,
-							Unsigned:      false,
-							NotNull:       false,
-							Default:       nil,
-							Primary:       false,
-							AutoIncrement: false,
-							Values:        nilValues,
-						},
-					},
-					Indexes: []*config.Index{
-						{
-							Name:    "",
-							Primary: false,
-							Uniq:    false,
-							Columns: []string{
-								"col_1",
-							},
+							Name:       "col_1",
+							Type:       "varchar",
+							Order:      20,
+							Values:     nilValues,
+							ValuesFile: "./values/products.csv",
 						},
 					},
+					Indexes: nilIndexes,
 					Charset: "utf8mb4",
 					Record:  100000,
 				},
@@ -1558,7 +1750,7 @@ func Test_LoadConfig_Indexes(t *testing.T) {
 		},
 
 		{
-			name: "missing a uniq of index in indexes part",
+			name: "both of values and valuesFile of column in columns part",
 			yaml: []byte(`
                 database:
                   driver: mysql
@@ -1571,13 +1763,11 @@ func Test_LoadConfig_Indexes(t *testing.T) {
                 - name: table_a
                   columns:
                     - name: col_1
-                      type: float
-                      order: 5
-                      precision: 2
-                  indexes:
-                    - primary: true
-                      columns:
-                        - col_1
+                      type: varchar
+                      order: 20
+                      values:
+                        - "YES"
+                      valuesFile: ./values/products.csv
                   charset: utf8mb4
                   record: 100000
             `),
@@ -1586,8 +1776,511 @@ func Test_LoadConfig_Indexes(t *testing.T) {
 					Name: "table_a",
 					Columns: []*config.Column{
 						{
-							Name:          "col_1",
-							Type:          "float",
+							Name:       "col_1",
+							Type:       "varchar",
+							Order:      20,
+							Values:     []interface{}{"YES"},
+							ValuesFile: "./values/products.csv",
+						},
+					},
+					Indexes: nilIndexes,
+					Charset: "utf8mb4",
+					Record:  100000,
+				},
+			},
+			err: errors.New("both of values and valuesFile cannot be set"),
+		},
+
+		{
+			name: "unmatched weights of column in columns part",
+			yaml: []byte(`
+                database:
+                  driver: mysql
+                  host: 127.0.0.1
+                  port: 3306
+                  user: root
+                  password: root
+                  name: testdb
+                tables:
+                - name: table_a
+                  columns:
+                    - name: col_1
+                      type: varchar
+                      order: 20
+                      values:
+                        - "YES"
+                        - "NO"
+                      weights:
+                        - 9
+                  charset: utf8mb4
+                  record: 100000
+            `),
+			config: []*config.Table{
+				{
+					Name: "table_a",
+					Columns: []*config.Column{
+						{
+							Name:    "col_1",
+							Type:    "varchar",
+							Order:   20,
+							Values:  []interface{}{"YES", "NO"},
+							Weights: []float64{9},
+						},
+					},
+					Indexes: nilIndexes,
+					Charset: "utf8mb4",
+					Record:  100000,
+				},
+			},
+			err: errors.New("the number of weights must match the number of values"),
+		},
+
+		{
+			name: "valuesQuery of column in columns part",
+			yaml: []byte(`
+                database:
+                  driver: mysql
+                  host: 127.0.0.1
+                  port: 3306
+                  user: root
+                  password: root
+                  name: testdb
+                tables:
+                - name: table_a
+                  columns:
+                    - name: col_1
+                      type: char
+                      order: 2
+                      valuesQuery: SELECT code FROM countries
+                  charset: utf8mb4
+                  record: 100000
+            `),
+			config: []*config.Table{
+				{
+					Name: "table_a",
+					Columns: []*config.Column{
+						{
+							Name:        "col_1",
+							Type:        "char",
+							Order:       2,
+							Values:      nilValues,
+							ValuesQuery: "SELECT code FROM countries",
+						},
+					},
+					Indexes: nilIndexes,
+					Charset: "utf8mb4",
+					Record:  100000,
+				},
+			},
+			err: nil,
+		},
+
+		{
+			name: "non-select valuesQuery of column in columns part",
+			yaml: []byte(`
+                database:
+                  driver: mysql
+                  host: 127.0.0.1
+                  port: 3306
+                  user: root
+                  password: root
+                  name: testdb
+                tables:
+                - name: table_a
+                  columns:
+                    - name: col_1
+                      type: char
+                      order: 2
+                      valuesQuery: DELETE FROM countries
+                  charset: utf8mb4
+                  record: 100000
+            `),
+			config: []*config.Table{
+				{
+					Name: "table_a",
+					Columns: []*config.Column{
+						{
+							Name:        "col_1",
+							Type:        "char",
+							Order:       2,
+							Values:      nilValues,
+							ValuesQuery: "DELETE FROM countries",
+						},
+					},
+					Indexes: nilIndexes,
+					Charset: "utf8mb4",
+					Record:  100000,
+				},
+			},
+			err: errors.New("valuesQuery must be a SELECT statement"),
+		},
+
+		{
+			name: "document of non-json column in columns part",
+			yaml: []byte(`
+                database:
+                  driver: mysql
+                  host: 127.0.0.1
+                  port: 3306
+                  user: root
+                  password: root
+                  name: testdb
+                tables:
+                - name: table_a
+                  columns:
+                    - name: col_1
+                      type: text
+                      document:
+                        type: boolean
+                  charset: utf8mb4
+                  record: 100000
+            `),
+			config: []*config.Table{
+				{
+					Name: "table_a",
+					Columns: []*config.Column{
+						{
+							Name:     "col_1",
+							Type:     "text",
+							Values:   nilValues,
+							Document: &config.Document{Type: "boolean"},
+						},
+					},
+					Indexes: nilIndexes,
+					Charset: "utf8mb4",
+					Record:  100000,
+				},
+			},
+			err: errors.New("document is only available for json column"),
+		},
+
+		{
+			name: "enum w/ non-member default in columns part",
+			yaml: []byte(`
+                database:
+                  driver: mysql
+                  host: 127.0.0.1
+                  port: 3306
+                  user: root
+                  password: root
+                  name: testdb
+                tables:
+                - name: table_a
+                  columns:
+                    - name: col_1
+                      type: enum
+                      default: large
+                      values:
+                        - small
+                        - medium
+                  charset: utf8mb4
+                  record: 100000
+            `),
+			config: []*config.Table{
+				{
+					Name: "table_a",
+					Columns: []*config.Column{
+						{
+							Name:    "col_1",
+							Type:    "enum",
+							Default: "large",
+							Values:  []interface{}{"small", "medium"},
+						},
+					},
+					Indexes: nilIndexes,
+					Charset: "utf8mb4",
+					Record:  100000,
+				},
+			},
+			err: errors.New("default \"large\" is not a member of enum column"),
+		},
+
+		{
+			name: "set w/ member default in columns part",
+			yaml: []byte(`
+                database:
+                  driver: mysql
+                  host: 127.0.0.1
+                  port: 3306
+                  user: root
+                  password: root
+                  name: testdb
+                tables:
+                - name: table_a
+                  columns:
+                    - name: col_1
+                      type: set
+                      default: read,write
+                      values:
+                        - read
+                        - write
+                  charset: utf8mb4
+                  record: 100000
+            `),
+			config: []*config.Table{
+				{
+					Name: "table_a",
+					Columns: []*config.Column{
+						{
+							Name:    "col_1",
+							Type:    "set",
+							Default: "read,write",
+							Values:  []interface{}{"read", "write"},
+						},
+					},
+					Indexes: nilIndexes,
+					Charset: "utf8mb4",
+					Record:  100000,
+				},
+			},
+			err: nil,
+		},
+
+		{
+			name: "set w/o members in columns part",
+			yaml: []byte(`
+                database:
+                  driver: mysql
+                  host: 127.0.0.1
+                  port: 3306
+                  user: root
+                  password: root
+                  name: testdb
+                tables:
+                - name: table_a
+                  columns:
+                    - name: col_1
+                      type: set
+                  charset: utf8mb4
+                  record: 100000
+            `),
+			config: []*config.Table{
+				{
+					Name: "table_a",
+					Columns: []*config.Column{
+						{
+							Name:   "col_1",
+							Type:   "set",
+							Values: nilValues,
+						},
+					},
+					Indexes: nilIndexes,
+					Charset: "utf8mb4",
+					Record:  100000,
+				},
+			},
+			err: errors.New("set column requires its members as values"),
+		},
+
+		{
+			name: "out of range entropy of column in columns part",
+			yaml: []byte(`
+                database:
+                  driver: mysql
+                  host: 127.0.0.1
+                  port: 3306
+                  user: root
+                  password: root
+                  name: testdb
+                tables:
+                - name: table_a
+                  columns:
+                    - name: col_1
+                      type: varbinary
+                      order: 16
+                      entropy: 1.5
+                  charset: utf8mb4
+                  record: 100000
+            `),
+			config: []*config.Table{
+				{
+					Name: "table_a",
+					Columns: []*config.Column{
+						{
+							Name:    "col_1",
+							Type:    "varbinary",
+							Order:   16,
+							Values:  nilValues,
+							Entropy: &outOfRangeEntropy,
+						},
+					},
+					Indexes: nilIndexes,
+					Charset: "utf8mb4",
+					Record:  100000,
+				},
+			},
+			err: errors.New("entropy must be between 0 and 1"),
+		},
+
+		{
+			name: "generated column w/ default in columns part",
+			yaml: []byte(`
+                database:
+                  driver: mysql
+                  host: 127.0.0.1
+                  port: 3306
+                  user: root
+                  password: root
+                  name: testdb
+                tables:
+                - name: table_a
+                  columns:
+                    - name: col_1
+                      type: int
+                    - name: col_2
+                      type: int
+                      as: col_1 * 2
+                      default: 0
+                  charset: utf8mb4
+                  record: 100000
+            `),
+			config: []*config.Table{
+				{
+					Name: "table_a",
+					Columns: []*config.Column{
+						{
+							Name:   "col_1",
+							Type:   "int",
+							Values: nilValues,
+						},
+						{
+							Name:    "col_2",
+							Type:    "int",
+							As:      "col_1 * 2",
+							Default: 0,
+							Values:  nilValues,
+						},
+					},
+					Indexes: nilIndexes,
+					Charset: "utf8mb4",
+					Record:  100000,
+				},
+			},
+			err: errors.New("generated column cannot have default"),
+		},
+	}
+
+	//nolint:dupl
+	for _, c := range cases {
+		if err := viper.ReadConfig(bytes.NewBuffer(c.yaml)); err != nil {
+			t.Errorf("case: %s is failed, err: %s\n", c.name, err)
+		}
+
+		err := cmd.LoadConfig()
+		if !assert.Equal(t, c.err, err) {
+			t.Errorf("case: %s is failed, expected: %s, actual: %s\n", c.name, c.err, err)
+		}
+
+		cfg := config.Instance
+		if !assert.Equal(t, c.config, cfg.Tables) {
+			t.Errorf("case: %s is failed, expected: %+v, actual: %+v\n", c.name, c.config, cfg.Tables)
+		}
+
+		// reset global variable
+		config.Instance = nil
+	}
+}
+
+//nolint:funlen
+func Test_LoadConfig_Indexes(t *testing.T) {
+	viper.SetConfigType("yaml")
+
+	cases := []struct {
+		name   string
+		yaml   []byte
+		config interface{}
+		err    error
+	}{
+		{
+			name: "missing a name of index in indexes part",
+			yaml: []byte(`
+                database:
+                  driver: mysql
+                  host: 127.0.0.1
+                  port: 3306
+                  user: root
+                  password: root
+                  name: testdb
+                tables:
+                - name: table_a
+                  columns:
+                    - name: col_1
+                      type: float
+                      order: 5
+                      precision: 2
+                  indexes:
+                    - primary: false
+                      uniq: false
+                      columns:
+                        - col_1
+                  charset: utf8mb4
+                  record: 100000
+            `),
+			config: []*config.Table{
+				{
+					Name: "table_a",
+					Columns: []*config.Column{
+						{
+							Name:          "col_1",
+							Type:          "float",
+							Order:         5,
+							Precision:     2,
+							Unsigned:      false,
+							NotNull:       false,
+							Default:       nil,
+							Primary:       false,
+							AutoIncrement: false,
+							Values:        nilValues,
+						},
+					},
+					Indexes: []*config.Index{
+						{
+							Name:    "",
+							Primary: false,
+							Uniq:    false,
+							Columns: []string{
+								"col_1",
+							},
+						},
+					},
+					Charset: "utf8mb4",
+					Record:  100000,
+				},
+			},
+			err: nil,
+		},
+
+		{
+			name: "missing a uniq of index in indexes part",
+			yaml: []byte(`
+                database:
+                  driver: mysql
+                  host: 127.0.0.1
+                  port: 3306
+                  user: root
+                  password: root
+                  name: testdb
+                tables:
+                - name: table_a
+                  columns:
+                    - name: col_1
+                      type: float
+                      order: 5
+                      precision: 2
+                  indexes:
+                    - primary: true
+                      columns:
+                        - col_1
+                  charset: utf8mb4
+                  record: 100000
+            `),
+			config: []*config.Table{
+				{
+					Name: "table_a",
+					Columns: []*config.Column{
+						{
+							Name:          "col_1",
+							Type:          "float",
 							Order:         5,
 							Precision:     2,
 							Unsigned:      false,
@@ -1849,6 +2542,261 @@ func Test_LoadConfig_Indexes(t *testing.T) {
 			},
 			err: errors.New("both of primary key and unique key cannot be enabled"),
 		},
+
+		{
+			name: "spatial index on nullable column in indexes part",
+			yaml: []byte(`
+                database:
+                  driver: mysql
+                  host: 127.0.0.1
+                  port: 3306
+                  user: root
+                  password: root
+                  name: testdb
+                tables:
+                - name: table_a
+                  columns:
+                    - name: col_1
+                      type: point
+                      srid: 4326
+                  indexes:
+                    - name: index_1_on_table_a
+                      spatial: true
+                      columns:
+                        - col_1
+                  charset: utf8mb4
+                  record: 100000
+            `),
+			config: []*config.Table{
+				{
+					Name: "table_a",
+					Columns: []*config.Column{
+						{
+							Name:   "col_1",
+							Type:   "point",
+							SRID:   4326,
+							Values: nilValues,
+						},
+					},
+					Indexes: []*config.Index{
+						{
+							Name:    "index_1_on_table_a",
+							Spatial: true,
+							Columns: []string{
+								"col_1",
+							},
+						},
+					},
+					Charset: "utf8mb4",
+					Record:  100000,
+				},
+			},
+			err: errors.New("column of spatial index must be not null"),
+		},
+
+		{
+			name: "fulltext index on non-string column in indexes part",
+			yaml: []byte(`
+                database:
+                  driver: mysql
+                  host: 127.0.0.1
+                  port: 3306
+                  user: root
+                  password: root
+                  name: testdb
+                tables:
+                - name: table_a
+                  columns:
+                    - name: col_1
+                      type: int
+                  indexes:
+                    - name: index_1_on_table_a
+                      fulltext: true
+                      columns:
+                        - col_1
+                  charset: utf8mb4
+                  record: 100000
+            `),
+			config: []*config.Table{
+				{
+					Name: "table_a",
+					Columns: []*config.Column{
+						{
+							Name:   "col_1",
+							Type:   "int",
+							Values: nilValues,
+						},
+					},
+					Indexes: []*config.Index{
+						{
+							Name:     "index_1_on_table_a",
+							Fulltext: true,
+							Columns: []string{
+								"col_1",
+							},
+						},
+					},
+					Charset: "utf8mb4",
+					Record:  100000,
+				},
+			},
+			err: errors.New("fulltext index requires string column, but col_1 is int"),
+		},
+
+		{
+			name: "key parts in indexes part",
+			yaml: []byte(`
+                database:
+                  driver: mysql
+                  host: 127.0.0.1
+                  port: 3306
+                  user: root
+                  password: root
+                  name: testdb
+                tables:
+                - name: table_a
+                  columns:
+                    - name: col_1
+                      type: varchar
+                      order: 255
+                  indexes:
+                    - name: index_1_on_table_a
+                      using: btree
+                      invisible: true
+                      keys:
+                        - column: col_1
+                          length: 20
+                          order: desc
+                        - expr: lower(col_1)
+                  charset: utf8mb4
+                  record: 100000
+            `),
+			config: []*config.Table{
+				{
+					Name: "table_a",
+					Columns: []*config.Column{
+						{
+							Name:   "col_1",
+							Type:   "varchar",
+							Order:  255,
+							Values: nilValues,
+						},
+					},
+					Indexes: []*config.Index{
+						{
+							Name:      "index_1_on_table_a",
+							Using:     "btree",
+							Invisible: true,
+							Keys: []*config.KeyPart{
+								{Column: "col_1", Length: 20, Order: "desc"},
+								{Expr: "lower(col_1)"},
+							},
+						},
+					},
+					Charset: "utf8mb4",
+					Record:  100000,
+				},
+			},
+			err: nil,
+		},
+
+		{
+			name: "key part w/o length on text column in indexes part",
+			yaml: []byte(`
+                database:
+                  driver: mysql
+                  host: 127.0.0.1
+                  port: 3306
+                  user: root
+                  password: root
+                  name: testdb
+                tables:
+                - name: table_a
+                  columns:
+                    - name: col_1
+                      type: text
+                  indexes:
+                    - name: index_1_on_table_a
+                      columns:
+                        - col_1
+                  charset: utf8mb4
+                  record: 100000
+            `),
+			config: []*config.Table{
+				{
+					Name: "table_a",
+					Columns: []*config.Column{
+						{
+							Name:   "col_1",
+							Type:   "text",
+							Values: nilValues,
+						},
+					},
+					Indexes: []*config.Index{
+						{
+							Name: "index_1_on_table_a",
+							Columns: []string{
+								"col_1",
+							},
+						},
+					},
+					Charset: "utf8mb4",
+					Record:  100000,
+				},
+			},
+			err: errors.New("key part col_1 of text column requires length"),
+		},
+
+		{
+			name: "fulltext index on long varchar column in indexes part",
+			yaml: []byte(`
+                database:
+                  driver: mysql
+                  host: 127.0.0.1
+                  port: 3306
+                  user: root
+                  password: root
+                  name: testdb
+                tables:
+                - name: table_a
+                  columns:
+                    - name: body
+                      type: varchar
+                      order: 1000
+                  indexes:
+                    - name: index_1_on_table_a
+                      fulltext: true
+                      columns:
+                        - body
+                  charset: utf8mb4
+                  record: 100000
+            `),
+			config: []*config.Table{
+				{
+					Name: "table_a",
+					Columns: []*config.Column{
+						{
+							Name:   "body",
+							Type:   "varchar",
+							Order:  1000,
+							Values: nilValues,
+						},
+					},
+					Indexes: []*config.Index{
+						{
+							Name:     "index_1_on_table_a",
+							Fulltext: true,
+							Columns: []string{
+								"body",
+							},
+						},
+					},
+					Charset: "utf8mb4",
+					Record:  100000,
+				},
+			},
+			err: nil,
+		},
 	}
 
 	//nolint:dupl
@@ -1871,3 +2819,263 @@ func Test_LoadConfig_Indexes(t *testing.T) {
 		config.Instance = nil
 	}
 }
+
+func Test_LoadValuePools(t *testing.T) {
+	viper.SetConfigType("yaml")
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "products.txt"), []byte("apple\nbanana\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	yaml := []byte(`
+        database:
+          driver: mysql
+          user: root
+          name: testdb
+        tables:
+        - name: table_a
+          columns:
+            - name: col_1
+              type: varchar
+              valuesFile: products.txt
+        - name: table_b
+          columns:
+            - name: col_1
+              type: varchar
+              valuesFile: products.txt
+            - name: col_2
+              type: varchar
+              values:
+                - "YES"
+                - "NO"
+              weights:
+                - 9
+                - 1
+    `)
+
+	if err := viper.ReadConfig(bytes.NewBuffer(yaml)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cmd.LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := config.Instance.LoadValuePools(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	colA := config.Instance.Tables[0].Columns[0]
+	colB := config.Instance.Tables[1].Columns[0]
+	assert.Equal(t, []interface{}{"apple", "banana"}, colA.Pool().Values)
+	assert.Same(t, colA.Pool(), colB.Pool())
+
+	colC := config.Instance.Tables[1].Columns[1]
+	assert.Equal(t, []float64{9, 1}, colC.Pool().Weights)
+
+	// reset global variable
+	config.Instance = nil
+}
+
+func Test_CompleteWithDefault_OnError(t *testing.T) {
+	viper.SetConfigType("yaml")
+
+	yaml := []byte(`
+        database:
+          driver: mysql
+          user: root
+          name: testdb
+        onError: skip
+        tables:
+        - name: table_a
+          columns:
+            - name: col_1
+              type: int
+        - name: table_b
+          onError: Retry
+          columns:
+            - name: col_1
+              type: int
+    `)
+
+	if err := viper.ReadConfig(bytes.NewBuffer(yaml)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cmd.LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	config.Instance.CompleteWithDefault()
+
+	assert.Equal(t, config.OnErrorSkip, config.Instance.Tables[0].OnError)
+	assert.Equal(t, config.OnErrorRetry, config.Instance.Tables[1].OnError)
+
+	// reset global variable
+	config.Instance = nil
+}
+
+func Test_CompleteWithDefault_Batch(t *testing.T) {
+	viper.SetConfigType("yaml")
+
+	yaml := []byte(`
+        database:
+          driver: mysql
+          user: root
+          name: testdb
+        batchBytes: 1048576
+        tables:
+        - name: table_a
+          columns:
+            - name: col_1
+              type: int
+        - name: table_b
+          batchSize: 50
+          batchBytes: 65536
+          columns:
+            - name: col_1
+              type: longtext
+    `)
+
+	if err := viper.ReadConfig(bytes.NewBuffer(yaml)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cmd.LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	config.Instance.CompleteWithDefault()
+
+	assert.Equal(t, config.DefaultBatchSize, config.Instance.Tables[0].BatchSize)
+	assert.Equal(t, 1048576, config.Instance.Tables[0].BatchBytes)
+	assert.Equal(t, 50, config.Instance.Tables[1].BatchSize)
+	assert.Equal(t, 65536, config.Instance.Tables[1].BatchBytes)
+
+	// reset global variable
+	config.Instance = nil
+}
+
+func Test_CompleteWithDefault_AutoBatch(t *testing.T) {
+	viper.SetConfigType("yaml")
+
+	yaml := []byte(`
+        database:
+          driver: mysql
+          user: root
+          name: testdb
+        concurrency: 4
+        autoBatch:
+          maxSize: 5000
+        tables:
+        - name: table_a
+          columns:
+            - name: col_1
+              type: int
+        - name: table_b
+          autoBatch:
+            maxConcurrency: 16
+            maxLatency: 500ms
+          columns:
+            - name: col_1
+              type: int
+        - name: table_c
+          concurrency: 8
+          autoBatch: {}
+          columns:
+            - name: col_1
+              type: int
+    `)
+
+	if err := viper.ReadConfig(bytes.NewBuffer(yaml)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cmd.LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	config.Instance.CompleteWithDefault()
+
+	assert.Equal(t, &config.AutoBatch{
+		MinSize:        10,
+		MaxSize:        5000,
+		MinConcurrency: 1,
+		MaxConcurrency: 4,
+		MaxLatency:     time.Second,
+	}, config.Instance.Tables[0].AutoBatch)
+	assert.Equal(t, &config.AutoBatch{
+		MinSize:        10,
+		MaxSize:        10000,
+		MinConcurrency: 1,
+		MaxConcurrency: 16,
+		MaxLatency:     500 * time.Millisecond,
+	}, config.Instance.Tables[1].AutoBatch)
+	assert.Equal(t, &config.AutoBatch{
+		MinSize:        10,
+		MaxSize:        10000,
+		MinConcurrency: 1,
+		MaxConcurrency: 8,
+		MaxLatency:     time.Second,
+	}, config.Instance.Tables[2].AutoBatch)
+	assert.Equal(t, 16, config.Instance.MaxConcurrency())
+
+	// reset global variable
+	config.Instance = nil
+}
+
+func Test_CompleteWithDefault_Throttle(t *testing.T) {
+	viper.SetConfigType("yaml")
+
+	yaml := []byte(`
+        database:
+          driver: mysql
+          user: root
+          name: testdb
+        throttle:
+          maxThreadsRunning: 50
+          status:
+            - name: Threads_connected
+              max: 500
+          replicas:
+            - root:root@tcp(replica:3306)/
+        tables:
+        - name: table_a
+          columns:
+            - name: col_1
+              type: int
+        - name: table_b
+          throttle:
+            interval: 5s
+            maxThreadsRunning: 10
+          columns:
+            - name: col_1
+              type: int
+    `)
+
+	if err := viper.ReadConfig(bytes.NewBuffer(yaml)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cmd.LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	config.Instance.CompleteWithDefault()
+
+	assert.Equal(t, &config.Throttle{
+		Interval:          time.Second,
+		MaxThreadsRunning: 50,
+		Status:            []*config.StatusThreshold{{Name: "Threads_connected", Max: 500}},
+		Replicas:          []string{"root:root@tcp(replica:3306)/"},
+		MaxLag:            time.Second,
+	}, config.Instance.Tables[0].Throttle)
+	assert.Equal(t, &config.Throttle{
+		Interval:          5 * time.Second,
+		MaxThreadsRunning: 10,
+	}, config.Instance.Tables[1].Throttle)
+
+	// reset global variable
+	config.Instance = nil
+}
@@ -0,0 +1,80 @@
+/*
+Package config ...
+
+Copyright © 2019 hajime-terasawa <terako.studio@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+// AutoBatch represents the bounds of batch size and concurrency tuned while populating.
+type AutoBatch struct {
+	MinSize        int
+	MaxSize        int
+	MinConcurrency int
+	MaxConcurrency int
+	// MaxLatency shrinks batches taking longer than it.
+	MaxLatency time.Duration
+}
+
+const (
+	defaultAutoBatchMinSize    = 10
+	defaultAutoBatchMaxSize    = 10000
+	defaultAutoBatchMaxLatency = time.Second
+)
+
+// CompleteWithDefault complete config value which is not required but configurable.
+// Concurrency is up to the configured one by default.
+func (a *AutoBatch) CompleteWithDefault(concurrency int) {
+	if a.MinSize == 0 {
+		a.MinSize = defaultAutoBatchMinSize
+	}
+
+	if a.MaxSize == 0 {
+		a.MaxSize = defaultAutoBatchMaxSize
+	}
+
+	if a.MinConcurrency == 0 {
+		a.MinConcurrency = 1
+	}
+
+	if a.MaxConcurrency == 0 {
+		a.MaxConcurrency = max(concurrency, a.MinConcurrency)
+	}
+
+	if a.MaxLatency == 0 {
+		a.MaxLatency = defaultAutoBatchMaxLatency
+	}
+}
+
+// Validate validates auto batch config.
+func (a *AutoBatch) Validate() error {
+	if a.MinSize < 0 || a.MaxSize < 0 || a.MinConcurrency < 0 || a.MaxConcurrency < 0 || a.MaxLatency < 0 {
+		return errors.New("bounds of autoBatch must be positive")
+	}
+
+	if a.MaxSize != 0 && a.MinSize > a.MaxSize {
+		return errors.New("minSize of autoBatch must be less than or equal to maxSize")
+	}
+
+	if a.MaxConcurrency != 0 && a.MinConcurrency > a.MaxConcurrency {
+		return errors.New("minConcurrency of autoBatch must be less than or equal to maxConcurrency")
+	}
+
+	return nil
+}
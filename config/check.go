@@ -0,0 +1,279 @@
+/*
+Package config ...
+
+Copyright © 2019 hajime-terasawa <terako.studio@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Check represents a CHECK constraint of a table.
+type Check struct {
+	Name string
+	Expr string
+}
+
+// Validate validates check config.
+func (c *Check) Validate() error {
+	if c == nil || strings.TrimSpace(c.Expr) == "" {
+		return errors.New("check constraint requires expr")
+	}
+
+	return validateIdentifier("check constraint", c.Name)
+}
+
+const identPattern = "`?([A-Za-z0-9_$]+)`?"
+
+var (
+	betweenRe = regexp.MustCompile(`(?i)` + identPattern + `\s+BETWEEN\s+(-?[0-9.]+)\s+AND\s+(-?[0-9.]+)`)
+	andRe     = regexp.MustCompile(`(?i)\s+AND\s+`)
+	orRe      = regexp.MustCompile(`(?i)\b(OR|NOT|XOR)\b|\|\|`)
+	compareRe = regexp.MustCompile(`^` + identPattern + `\s*(>=|<=|>|<)\s*(-?[0-9.]+)$`)
+	inRe      = regexp.MustCompile(`(?i)^` + identPattern + `\s+IN\s*\((.*)\)$`)
+)
+
+// columnRange is a constraint on column values derived from a CHECK expression.
+type columnRange struct {
+	min    *float64
+	max    *float64
+	values []interface{}
+}
+
+// parseCheck extracts constraints from simple CHECK forms, the conjunction of
+// `col BETWEEN a AND b`, `col >= a`, `col < b` and `col IN (...)`.
+// The other forms are just emitted to DDL and ignored on generation.
+func parseCheck(expr string) map[string]*columnRange {
+	ranges := make(map[string]*columnRange)
+	get := func(name string) *columnRange {
+		if _, ok := ranges[name]; !ok {
+			ranges[name] = &columnRange{}
+		}
+
+		return ranges[name]
+	}
+
+	// disjunction and negation cannot be narrowed to a range
+	if orRe.MatchString(expr) {
+		return ranges
+	}
+
+	expr = trimParens(expr)
+
+	for _, m := range betweenRe.FindAllStringSubmatch(expr, -1) {
+		lo, err1 := strconv.ParseFloat(m[2], 64)
+		hi, err2 := strconv.ParseFloat(m[3], 64)
+
+		if err1 == nil && err2 == nil {
+			r := get(m[1])
+			r.restrictMin(lo)
+			r.restrictMax(hi)
+		}
+	}
+
+	expr = betweenRe.ReplaceAllString(expr, "")
+
+	for _, part := range andRe.Split(expr, -1) {
+		part = trimParens(trimUnbalanced(part))
+
+		if m := compareRe.FindStringSubmatch(part); m != nil {
+			v, err := strconv.ParseFloat(m[3], 64)
+			if err != nil {
+				continue
+			}
+
+			r := get(m[1])
+
+			switch m[2] {
+			case ">=":
+				r.restrictMin(v)
+			case ">":
+				r.restrictMin(math.Nextafter(v, math.Inf(1)))
+			case "<=":
+				r.restrictMax(v)
+			case "<":
+				r.restrictMax(math.Nextafter(v, math.Inf(-1)))
+			}
+
+			continue
+		}
+
+		if m := inRe.FindStringSubmatch(part); m != nil {
+			get(m[1]).values = parseList(m[2])
+		}
+	}
+
+	return ranges
+}
+
+func (r *columnRange) restrictMin(v float64) {
+	if r.min == nil || *r.min < v {
+		r.min = &v
+	}
+}
+
+func (r *columnRange) restrictMax(v float64) {
+	if r.max == nil || *r.max > v {
+		r.max = &v
+	}
+}
+
+// trimParens trims spaces and parentheses wrapping the whole expression.
+func trimParens(s string) string {
+	s = strings.TrimSpace(s)
+
+	for strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		inner := s[1 : len(s)-1]
+		if !balanced(inner) {
+			break
+		}
+
+		s = strings.TrimSpace(inner)
+	}
+
+	return s
+}
+
+// trimUnbalanced trims parentheses left by splitting a grouped conjunction.
+func trimUnbalanced(s string) string {
+	s = strings.TrimSpace(s)
+
+	for strings.Count(s, "(") > strings.Count(s, ")") && strings.HasPrefix(s, "(") {
+		s = strings.TrimSpace(s[1:])
+	}
+
+	for strings.Count(s, ")") > strings.Count(s, "(") && strings.HasSuffix(s, ")") {
+		s = strings.TrimSpace(s[:len(s)-1])
+	}
+
+	return s
+}
+
+func balanced(s string) bool {
+	depth := 0
+
+	for _, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return false
+			}
+		}
+	}
+
+	return depth == 0
+}
+
+// parseList parses comma separated literals of IN list, quoted strings or numbers.
+func parseList(s string) []interface{} {
+	var (
+		values []interface{}
+		sb     strings.Builder
+		quote  rune
+		quoted bool
+	)
+
+	flush := func() {
+		item := sb.String()
+		sb.Reset()
+
+		if quoted {
+			values = append(values, item)
+			quoted = false
+
+			return
+		}
+
+		item = strings.TrimSpace(item)
+		if item == "" {
+			return
+		}
+
+		if n, err := strconv.ParseInt(item, 10, 64); err == nil {
+			values = append(values, int(n))
+		} else if f, err := strconv.ParseFloat(item, 64); err == nil {
+			values = append(values, f)
+		} else {
+			values = append(values, item)
+		}
+	}
+
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch {
+		case quote != 0 && r == quote:
+			// doubled quote is an escaped quote
+			if i+1 < len(runes) && runes[i+1] == quote {
+				sb.WriteRune(r)
+				i++
+
+				continue
+			}
+
+			quote = 0
+		case quote != 0:
+			sb.WriteRune(r)
+		case r == '\'' || r == '"':
+			sb.Reset()
+
+			quote = r
+			quoted = true
+		case r == ',':
+			flush()
+		case quoted && unicode.IsSpace(r):
+		default:
+			sb.WriteRune(r)
+		}
+	}
+
+	flush()
+
+	return values
+}
+
+// applyChecks narrows the values of columns by the CHECK constraints of the table.
+func (t *Table) applyChecks() {
+	for _, check := range t.Checks {
+		for name, r := range parseCheck(check.Expr) {
+			column := t.Column(name)
+			if column == nil {
+				continue
+			}
+
+			if r.min != nil && (column.Min == nil || *column.Min < *r.min) {
+				column.Min = r.min
+			}
+
+			if r.max != nil && (column.Max == nil || *column.Max > *r.max) {
+				column.Max = r.max
+			}
+
+			if len(r.values) > 0 && len(column.Values) == 0 && column.ValuesFile == "" && column.ValuesQuery == "" {
+				column.Values = r.values
+			}
+		}
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Package config ...
+
+Copyright © 2019 hajime-terasawa <terako.studio@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/terakoya76/populator/config"
+)
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
+func Test_Table_CompleteWithDefault_Checks(t *testing.T) {
+	var nilFloat *float64
+
+	cases := []struct {
+		name   string
+		expr   string
+		min    *float64
+		max    *float64
+		values []interface{}
+	}{
+		{
+			name:   "between",
+			expr:   "col_1 BETWEEN 1 AND 100",
+			min:    float64Ptr(1),
+			max:    float64Ptr(100),
+			values: nil,
+		},
+
+		{
+			name:   "comparisons",
+			expr:   "(`col_1` >= 0 AND col_1 <= 9.5) AND col_2 > 3",
+			min:    float64Ptr(0),
+			max:    float64Ptr(9.5),
+			values: nil,
+		},
+
+		{
+			name:   "in list",
+			expr:   "col_1 IN ('a', 'it''s', 3)",
+			min:    nilFloat,
+			max:    nilFloat,
+			values: []interface{}{"a", "it's", 3},
+		},
+
+		{
+			name:   "disjunction is ignored",
+			expr:   "col_1 < 0 OR col_1 > 10",
+			min:    nilFloat,
+			max:    nilFloat,
+			values: nil,
+		},
+	}
+
+	for _, c := range cases {
+		table := &config.Table{
+			Name: "table_a",
+			Columns: []*config.Column{
+				{Name: "col_1", Type: "decimal", Order: 5, Precision: 2},
+				{Name: "col_2", Type: "int"},
+			},
+			Checks: []*config.Check{
+				{Expr: c.expr},
+			},
+		}
+		table.CompleteWithDefault()
+
+		column := table.Column("col_1")
+		if !assert.Equal(t, c.min, column.Min) || !assert.Equal(t, c.max, column.Max) || !assert.Equal(t, c.values, column.Values) {
+			t.Errorf("case: %s is failed, actual: %v %v %v\n", c.name, column.Min, column.Max, column.Values)
+		}
+	}
+}
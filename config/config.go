@@ -19,6 +19,13 @@ package config
 
 import (
 	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/terakoya76/populator/rand"
+	"github.com/terakoya76/populator/utils"
 )
 
 // Instance represents the both information of the connecting database and the tables schema to be populated w/ seed data.
@@ -27,17 +34,109 @@ var Instance *config
 type config struct {
 	Database *Database
 	Tables   []*Table
+	// OnError is the default failure policy of tables.
+	OnError string
+	// Concurrency is the number of workers inserting records, which is also the limit of open connections.
+	Concurrency int
+	// BatchSize and BatchBytes are the default limits of records and bytes in an insert statement.
+	BatchSize  int
+	BatchBytes int
+	// AutoBatch tunes batch size and concurrency of tables while populating.
+	AutoBatch *AutoBatch
+	// Retry is how batches failed w/ transient errors are retried.
+	Retry *Retry
+	// Throttle pauses populating while the server is unhealthy.
+	Throttle *Throttle
 }
 
+// DefaultBatchSize is the number of records in an insert statement by default.
+const DefaultBatchSize = 200
+
+// Failure policies on insertion errors.
+const (
+	// OnErrorAbort stops populating at the first error.
+	OnErrorAbort = "abort"
+	// OnErrorSkip drops the failed batch, then continues populating.
+	OnErrorSkip = "skip"
+	// OnErrorRetry retries the failed batch unless it may have been committed, then stops populating when it keeps failing.
+	OnErrorRetry = "retry"
+)
+
 // CompleteWithDefault complete config value which is not required but configurable.
 func (c *config) CompleteWithDefault() {
 	c.Database.CompleteWithDefault()
 
+	if c.Concurrency == 0 {
+		c.Concurrency = runtime.NumCPU()
+	}
+
+	if c.BatchSize == 0 {
+		c.BatchSize = DefaultBatchSize
+	}
+
+	if c.AutoBatch != nil {
+		c.AutoBatch.CompleteWithDefault(c.Concurrency)
+	}
+
+	if c.Retry == nil {
+		c.Retry = &Retry{}
+	}
+
+	c.Retry.CompleteWithDefault()
+
+	if c.Throttle != nil {
+		c.Throttle.CompleteWithDefault()
+	}
+
+	c.OnError = strings.ToLower(c.OnError)
+	if c.OnError == "" {
+		c.OnError = OnErrorAbort
+	}
+
 	for _, table := range c.Tables {
-		table.CompleteWithDefault()
+		c.completeTable(table)
 	}
 }
 
+// completeTable complete table config value w/ the top level one as the default.
+func (c *config) completeTable(table *Table) {
+	if table.OnError == "" {
+		table.OnError = c.OnError
+	}
+
+	if table.Concurrency == 0 {
+		table.Concurrency = c.Concurrency
+	}
+
+	if table.BatchSize == 0 {
+		table.BatchSize = c.BatchSize
+	}
+
+	if table.BatchBytes == 0 {
+		table.BatchBytes = c.BatchBytes
+	}
+
+	if table.AutoBatch == nil {
+		table.AutoBatch = c.AutoBatch
+	} else {
+		table.AutoBatch.CompleteWithDefault(table.Concurrency)
+	}
+
+	if table.Retry == nil {
+		table.Retry = c.Retry
+	} else {
+		table.Retry.CompleteWithDefault()
+	}
+
+	if table.Throttle == nil {
+		table.Throttle = c.Throttle
+	} else {
+		table.Throttle.CompleteWithDefault()
+	}
+
+	table.CompleteWithDefault()
+}
+
 // Validate validates config.
 func (c *config) Validate() error {
 	if err := c.Database.Validate(); err != nil {
@@ -48,6 +147,36 @@ func (c *config) Validate() error {
 		return errors.New("tables definition is required")
 	}
 
+	if err := validateOnError(c.OnError); err != nil {
+		return err
+	}
+
+	if c.Concurrency < 0 {
+		return errors.New("concurrency must be positive")
+	}
+
+	if err := validateBatch(c.BatchSize, c.BatchBytes); err != nil {
+		return err
+	}
+
+	if c.AutoBatch != nil {
+		if err := c.AutoBatch.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.Retry != nil {
+		if err := c.Retry.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.Throttle != nil {
+		if err := c.Throttle.Validate(); err != nil {
+			return err
+		}
+	}
+
 	for _, table := range c.Tables {
 		if err := table.Validate(); err != nil {
 			return err
@@ -88,6 +217,57 @@ func (db *Database) Validate() error {
 		return errors.New("database name is required")
 	}
 
+	return validateIdentifier("database", db.Name)
+}
+
+// MaxConcurrency returns the max number of workers of tables, which limits open connections.
+func (c *config) MaxConcurrency() int {
+	concurrency := c.Concurrency
+
+	if c.AutoBatch != nil {
+		concurrency = max(concurrency, c.AutoBatch.MaxConcurrency)
+	}
+
+	for _, table := range c.Tables {
+		concurrency = max(concurrency, table.Concurrency)
+
+		if table.AutoBatch != nil {
+			concurrency = max(concurrency, table.AutoBatch.MaxConcurrency)
+		}
+	}
+
+	return concurrency
+}
+
+func validateBatch(size, bytes int) error {
+	if size < 0 {
+		return errors.New("batchSize must be positive")
+	}
+
+	if bytes < 0 {
+		return errors.New("batchBytes must be positive")
+	}
+
+	return nil
+}
+
+func validateOnError(policy string) error {
+	switch strings.ToLower(policy) {
+	case "", OnErrorAbort, OnErrorSkip, OnErrorRetry:
+		return nil
+	default:
+		return fmt.Errorf("onError %q is invalid, it must be one of abort, skip and retry", policy)
+	}
+}
+
+// maxIdentifierLength is the limit of characters of database, table, column, index and constraint names.
+const maxIdentifierLength = 64
+
+func validateIdentifier(kind, name string) error {
+	if utf8.RuneCountInString(name) > maxIdentifierLength {
+		return fmt.Errorf("%s name %s exceeds %d characters", kind, name, maxIdentifierLength)
+	}
+
 	return nil
 }
 
@@ -104,11 +284,21 @@ func (db *Database) CompleteWithDefault() {
 
 // Table represents a single table schema.
 type Table struct {
-	Name    string
-	Columns []*Column
-	Indexes []*Index
-	Charset string
-	Record  int
+	Name         string
+	Columns      []*Column
+	Indexes      []*Index
+	Checks       []*Check
+	Options      *TableOptions
+	Partitioning *Partitioning
+	Charset      string
+	Record       int
+	OnError      string
+	Concurrency  int
+	BatchSize    int
+	BatchBytes   int
+	AutoBatch    *AutoBatch
+	Retry        *Retry
+	Throttle     *Throttle
 }
 
 // CompleteWithDefault complete config value which is not required but configurable.
@@ -120,6 +310,18 @@ func (t *Table) CompleteWithDefault() {
 	for _, index := range t.Indexes {
 		index.CompleteWithDefault()
 	}
+
+	if t.Options != nil {
+		t.Options.CompleteWithDefault()
+	}
+
+	if t.Partitioning != nil {
+		t.Partitioning.CompleteWithDefault()
+	}
+
+	t.OnError = strings.ToLower(t.OnError)
+
+	t.applyChecks()
 }
 
 // Validate validates table config.
@@ -132,16 +334,322 @@ func (t *Table) Validate() error {
 		return errors.New("table name is required")
 	}
 
+	if err := validateIdentifier("table", t.Name); err != nil {
+		return err
+	}
+
+	if err := t.validatePopulation(); err != nil {
+		return err
+	}
+
 	for _, column := range t.Columns {
 		if err := column.Validate(); err != nil {
 			return err
 		}
 	}
 
+	if err := t.validateIndexes(); err != nil {
+		return err
+	}
+
+	for _, column := range t.Columns {
+		if err := t.validateAlphabet(column); err != nil {
+			return err
+		}
+	}
+
+	for _, check := range t.Checks {
+		if err := check.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if t.Options != nil {
+		if err := t.Options.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if t.Partitioning != nil {
+		if err := t.validatePartitioning(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// weightedRangeTypes are types of the column whose values are generated within a range partition.
+var weightedRangeTypes = []interface{}{"tinyint", "smallint", "mediumint", "int", "bigint", "date", "datetime", "timestamp"}
+
+// validatePartitioning validates partitioning against the columns of the table.
+func (t *Table) validatePartitioning() error {
+	p := t.Partitioning
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	name := p.Column()
+	column := t.Column(name)
+
+	if name != "" && column == nil {
+		return fmt.Errorf("partitioning column %s is not defined", name)
+	}
+
+	typ := strings.ToLower(p.Type)
+	if column != nil && strings.EqualFold(column.Type, "timestamp") && typ == "list" {
+		return fmt.Errorf("list partitioning over timestamp column %s is non-supported", name)
+	}
+
+	if len(p.Weights) == 0 && !p.Weighted() {
+		return nil
+	}
+
+	// weights are applied by generating values of the column landing on each partition
+	switch {
+	case column == nil:
+		return errors.New("weights of partitioning require a column as expr")
+	case column.AutoIncrement:
+		return fmt.Errorf("weights of partitioning are not available on auto_increment column %s", name)
+	case typ == "range" && !utils.Contains(weightedRangeTypes, strings.ToLower(column.Type)):
+		return fmt.Errorf("weights of range partitioning are not available on %s column %s", column.Type, name)
+	default:
+		return nil
+	}
+}
+
+// validatePopulation validates how records are inserted into the table.
+func (t *Table) validatePopulation() error {
+	if err := validateOnError(t.OnError); err != nil {
+		return err
+	}
+
+	if t.Concurrency < 0 {
+		return errors.New("concurrency must be positive")
+	}
+
+	if err := validateBatch(t.BatchSize, t.BatchBytes); err != nil {
+		return err
+	}
+
+	if t.AutoBatch != nil {
+		if err := t.AutoBatch.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if t.Retry != nil {
+		if err := t.Retry.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if t.Throttle != nil {
+		if err := t.Throttle.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *Table) validateIndexes() error {
 	for _, index := range t.Indexes {
 		if err := index.Validate(); err != nil {
 			return err
 		}
+
+		if err := t.validateSpatialIndex(index); err != nil {
+			return err
+		}
+
+		if err := t.validateFulltextIndex(index); err != nil {
+			return err
+		}
+
+		if err := t.validateIndexKeyLength(index); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// InsertableColumns returns columns whose values are given by insert statement.
+func (t *Table) InsertableColumns() []*Column {
+	columns := make([]*Column, 0, len(t.Columns))
+	for _, column := range t.Columns {
+		if column.IsGenerated() {
+			continue
+		}
+
+		columns = append(columns, column)
+	}
+
+	return columns
+}
+
+// Column returns the column w/ the given name.
+func (t *Table) Column(name string) *Column {
+	for _, column := range t.Columns {
+		if column.Name == name {
+			return column
+		}
+	}
+
+	return nil
+}
+
+// maxIndexKeyBytes is the limit of index key length of InnoDB w/ DYNAMIC or COMPRESSED row format.
+const maxIndexKeyBytes = 3072
+
+// CharsetMaxBytes returns the maximum byte length of a character in the given charset.
+func CharsetMaxBytes(charset string) int {
+	//nolint:mnd
+	switch strings.ToLower(charset) {
+	case "latin1", "ascii", "binary":
+		return 1
+	case "sjis", "cp932", "gbk", "big5", "euckr":
+		return 2
+	case "utf8", "utf8mb3", "ujis", "eucjpms":
+		return 3
+	default:
+		return 4
+	}
+}
+
+// validateAlphabet validates the alphabet of the column can be stored in the charset of the table.
+func (t *Table) validateAlphabet(c *Column) error {
+	if c.Alphabet == "" || c.Alphabet == "ascii" {
+		return nil
+	}
+
+	var ok bool
+
+	switch strings.ToLower(t.Charset) {
+	case "latin1":
+		ok = c.Alphabet == "latin1"
+	case "ascii", "binary":
+		ok = false
+	case "utf8", "utf8mb3":
+		// emoji requires 4 bytes
+		ok = c.Alphabet == "latin1" || c.Alphabet == "cjk"
+	default:
+		ok = true
+	}
+
+	if !ok {
+		return fmt.Errorf("alphabet %s of column %s cannot be stored in charset %s", c.Alphabet, c.Name, t.Charset)
+	}
+
+	return nil
+}
+
+// validateIndexKeyLength validates the byte length of the index key doesn't exceed the limit.
+func (t *Table) validateIndexKeyLength(i *Index) error {
+	// fulltext index is not a B-tree, so the limit of key length is not applied
+	if i.Spatial || i.Fulltext {
+		return nil
+	}
+
+	var (
+		bytes int
+		names []string
+	)
+
+	for _, part := range i.KeyParts() {
+		column := t.Column(part.Column)
+		if column == nil {
+			continue
+		}
+
+		if err := validateKeyPartLength(column, part); err != nil {
+			return err
+		}
+
+		names = append(names, part.Column)
+
+		length := column.Order
+		if part.Length != 0 {
+			length = part.Length
+		}
+
+		switch column.Type {
+		case "char", "varchar", "tinytext", "text", "mediumtext", "longtext":
+			bytes += length * CharsetMaxBytes(t.Charset)
+		case "binary", "varbinary", "tinyblob", "blob", "mediumblob", "longblob":
+			bytes += length
+		default:
+		}
+	}
+
+	if bytes > maxIndexKeyBytes {
+		return fmt.Errorf(
+			"index key on %s is %d bytes in charset %s, exceeds %d bytes",
+			strings.Join(names, ", "), bytes, t.Charset, maxIndexKeyBytes,
+		)
+	}
+
+	return nil
+}
+
+// validateKeyPartLength validates the prefix length of the key part against the column type.
+func validateKeyPartLength(column *Column, part *KeyPart) error {
+	switch column.Type {
+	case "char", "varchar", "binary", "varbinary":
+		if part.Length > column.Order && column.Order != 0 {
+			return fmt.Errorf("length of key part %s exceeds the column length %d", part.Column, column.Order)
+		}
+	case "tinytext", "text", "mediumtext", "longtext", "tinyblob", "blob", "mediumblob", "longblob":
+		if part.Length == 0 {
+			return fmt.Errorf("key part %s of %s column requires length", part.Column, column.Type)
+		}
+	default:
+		if part.Length != 0 {
+			return fmt.Errorf("length is not available for key part %s of %s column", part.Column, column.Type)
+		}
+	}
+
+	return nil
+}
+
+func (t *Table) validateFulltextIndex(i *Index) error {
+	if !i.Fulltext {
+		return nil
+	}
+
+	for _, part := range i.KeyParts() {
+		column := t.Column(part.Column)
+		if column == nil {
+			return fmt.Errorf("column %s of fulltext index is not defined", part.Column)
+		}
+
+		switch column.Type {
+		case "char", "varchar", "tinytext", "text", "mediumtext", "longtext":
+		default:
+			return fmt.Errorf("fulltext index requires string column, but %s is %s", part.Column, column.Type)
+		}
+
+		if part.Length != 0 {
+			return fmt.Errorf("length is not available for key part %s of fulltext index", part.Column)
+		}
+	}
+
+	return nil
+}
+
+func (t *Table) validateSpatialIndex(i *Index) error {
+	if !i.Spatial {
+		return nil
+	}
+
+	column := t.Column(i.KeyParts()[0].Column)
+	if column == nil || !column.IsSpatial() {
+		return errors.New("spatial index requires a spatial column")
+	}
+
+	if !column.NotNull {
+		return errors.New("column of spatial index must be not null")
 	}
 
 	return nil
@@ -159,6 +667,24 @@ type Column struct {
 	Type          string
 	Default       interface{}
 	Values        []interface{}
+	Weights       []float64
+	ValuesFile    string
+	ValuesQuery   string
+	Document      *Document
+	SRID          int
+	Bounds        *Bounds
+	Entropy       *float64
+	Alphabet      string
+	As            string
+	Stored        bool
+	Collate       string
+	Comment       string
+	Min           *float64
+	Max           *float64
+	Words         *Words
+
+	pool  *ValuePool
+	words *rand.Words
 }
 
 // CompleteWithDefault complete config value which is not required but configurable.
@@ -170,6 +696,42 @@ func (c *Column) CompleteWithDefault() {
 			c.completePrecisionWithDefault()
 		}
 	}
+
+	if c.Document != nil {
+		c.Document.CompleteWithDefault()
+	}
+
+	if c.Words != nil {
+		c.Words.CompleteWithDefault()
+	}
+
+	if c.IsSpatial() && c.Bounds == nil {
+		c.Bounds = &Bounds{MinX: -180, MinY: -90, MaxX: 180, MaxY: 90}
+	}
+}
+
+// BinaryEntropy returns the ratio of random bytes in binary values, fully random by default.
+func (c *Column) BinaryEntropy() float64 {
+	if c.Entropy == nil {
+		return 1
+	}
+
+	return *c.Entropy
+}
+
+// IsGenerated returns whether the column is a generated column.
+func (c *Column) IsGenerated() bool {
+	return c.As != ""
+}
+
+// IsSpatial returns whether the column holds geometry values.
+func (c *Column) IsSpatial() bool {
+	switch c.Type {
+	case "geometry", "point", "linestring", "polygon":
+		return true
+	default:
+		return false
+	}
 }
 
 func (c *Column) completeOrderWithDefault() {
@@ -234,15 +796,210 @@ func (c *Column) completePrecisionWithDefault() {
 
 // Validate validates column config.
 func (c *Column) Validate() error {
+	if err := validateIdentifier("column", c.Name); err != nil {
+		return err
+	}
+
+	if c.ValuesFile != "" && len(c.Values) > 0 {
+		return errors.New("both of values and valuesFile cannot be set")
+	}
+
+	if c.ValuesQuery != "" {
+		if c.ValuesFile != "" || len(c.Values) > 0 {
+			return errors.New("valuesQuery cannot be set w/ values or valuesFile")
+		}
+
+		if !strings.HasPrefix(strings.ToLower(strings.TrimSpace(c.ValuesQuery)), "select") {
+			return errors.New("valuesQuery must be a SELECT statement")
+		}
+	}
+
+	if c.Type == "enum" || c.Type == "set" {
+		if err := c.validateMembers(); err != nil {
+			return err
+		}
+	}
+
+	if err := c.validateGenerated(); err != nil {
+		return err
+	}
+
+	switch c.Alphabet {
+	case "", "ascii", "latin1", "cjk", "emoji", "mixed":
+	default:
+		return fmt.Errorf("alphabet %q is invalid or non-supported", c.Alphabet)
+	}
+
+	if c.Words != nil {
+		switch c.Type {
+		case "char", "varchar", "tinytext", "text", "mediumtext", "longtext":
+		default:
+			return errors.New("words is only available for string column")
+		}
+
+		if err := c.Words.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.Min != nil && c.Max != nil && *c.Min > *c.Max {
+		return errors.New("min must not exceed max")
+	}
+
+	if c.Entropy != nil && (*c.Entropy < 0 || *c.Entropy > 1) {
+		return errors.New("entropy must be between 0 and 1")
+	}
+
+	if c.Bounds != nil {
+		if !c.IsSpatial() {
+			return errors.New("bounds is only available for spatial column")
+		}
+
+		if c.Bounds.MinX >= c.Bounds.MaxX || c.Bounds.MinY >= c.Bounds.MaxY {
+			return errors.New("bounds must satisfy minX < maxX and minY < maxY")
+		}
+	}
+
+	if c.Document != nil {
+		if c.Type != "json" {
+			return errors.New("document is only available for json column")
+		}
+
+		if err := c.Document.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if len(c.Weights) > 0 && len(c.Weights) != len(c.Values) {
+		return errors.New("the number of weights must match the number of values")
+	}
+
 	return nil
 }
 
+// validateGenerated validates options conflicting w/ generated column.
+func (c *Column) validateGenerated() error {
+	if !c.IsGenerated() {
+		if c.Stored {
+			return errors.New("stored is only available for generated column")
+		}
+
+		return nil
+	}
+
+	if c.AutoIncrement {
+		return errors.New("generated column cannot be auto increment")
+	}
+
+	if c.Default != nil {
+		return errors.New("generated column cannot have default")
+	}
+
+	if len(c.Values) > 0 || c.ValuesFile != "" || c.ValuesQuery != "" {
+		return errors.New("generated column cannot have values")
+	}
+
+	return nil
+}
+
+// validateMembers validates enum/set column whose members are given by values.
+func (c *Column) validateMembers() error {
+	if len(c.Values) == 0 {
+		return fmt.Errorf("%s column requires its members as values", c.Type)
+	}
+
+	if c.Default == nil {
+		return nil
+	}
+
+	members := make(map[string]bool, len(c.Values))
+	for _, v := range c.Values {
+		members[fmt.Sprint(v)] = true
+	}
+
+	defaults := []string{fmt.Sprint(c.Default)}
+	if c.Type == "set" {
+		defaults = strings.Split(fmt.Sprint(c.Default), ",")
+	}
+
+	for _, d := range defaults {
+		if d == "" && c.Type == "set" {
+			continue
+		}
+
+		if !members[d] {
+			return fmt.Errorf("default %q is not a member of %s column", d, c.Type)
+		}
+	}
+
+	return nil
+}
+
+// Bounds represents a bounding box where geometry values are generated.
+type Bounds struct {
+	MinX float64
+	MinY float64
+	MaxX float64
+	MaxY float64
+}
+
 // Index represents a single index schema.
 type Index struct {
-	Name    string
-	Primary bool
-	Uniq    bool
-	Columns []string
+	Name      string
+	Primary   bool
+	Uniq      bool
+	Spatial   bool
+	Fulltext  bool
+	Parser    string
+	Invisible bool
+	Using     string
+	Columns   []string
+	Keys      []*KeyPart
+}
+
+// KeyPart represents a single key part of an index, a column w/ options or an expression.
+type KeyPart struct {
+	Column string
+	Length int
+	Order  string
+	Expr   string
+}
+
+// Validate validates key part config.
+func (k *KeyPart) Validate() error {
+	if (k.Column == "") == (k.Expr == "") {
+		return errors.New("key part requires either of column or expr")
+	}
+
+	if k.Expr != "" && k.Length != 0 {
+		return errors.New("length is not available for expression key part")
+	}
+
+	if k.Length < 0 {
+		return errors.New("length of key part must be positive")
+	}
+
+	switch strings.ToUpper(k.Order) {
+	case "", "ASC", "DESC":
+	default:
+		return fmt.Errorf("order %q of key part is invalid", k.Order)
+	}
+
+	return nil
+}
+
+// KeyParts returns key parts of the index, columns are converted into key parts w/o options.
+func (i *Index) KeyParts() []*KeyPart {
+	if len(i.Keys) > 0 {
+		return i.Keys
+	}
+
+	parts := make([]*KeyPart, 0, len(i.Columns))
+	for _, column := range i.Columns {
+		parts = append(parts, &KeyPart{Column: column})
+	}
+
+	return parts
 }
 
 // CompleteWithDefault complete config value which is not required but configurable.
@@ -251,6 +1008,10 @@ func (i *Index) CompleteWithDefault() {
 
 // Validate validates index config.
 func (i *Index) Validate() error {
+	if err := validateIdentifier("index", i.Name); err != nil {
+		return err
+	}
+
 	if i.Primary {
 		if i.Name != "" {
 			return errors.New("primary key index cannot be named")
@@ -261,5 +1022,73 @@ func (i *Index) Validate() error {
 		}
 	}
 
+	if i.Fulltext {
+		if i.Primary || i.Uniq || i.Spatial {
+			return errors.New("fulltext index cannot be primary key, unique key or spatial index")
+		}
+	}
+
+	if i.Parser != "" {
+		if !i.Fulltext {
+			return errors.New("parser is only available for fulltext index")
+		}
+
+		switch i.Parser {
+		case "ngram", "mecab":
+		default:
+			return fmt.Errorf("fulltext parser %q is invalid or non-supported", i.Parser)
+		}
+	}
+
+	if len(i.Columns) > 0 && len(i.Keys) > 0 {
+		return errors.New("both of columns and keys cannot be set")
+	}
+
+	if err := i.validateKeyParts(); err != nil {
+		return err
+	}
+
+	if i.Spatial {
+		if i.Primary || i.Uniq {
+			return errors.New("spatial index cannot be primary key or unique key")
+		}
+
+		if len(i.KeyParts()) != 1 || i.KeyParts()[0].Column == "" {
+			return errors.New("spatial index must consist of a single column")
+		}
+	}
+
+	if i.Primary && i.Invisible {
+		return errors.New("primary key cannot be invisible")
+	}
+
+	switch strings.ToUpper(i.Using) {
+	case "", "BTREE", "HASH":
+	default:
+		return fmt.Errorf("index type %q is invalid or non-supported", i.Using)
+	}
+
+	if i.Using != "" && (i.Spatial || i.Fulltext) {
+		return errors.New("index type is not available for spatial or fulltext index")
+	}
+
+	return nil
+}
+
+func (i *Index) validateKeyParts() error {
+	for _, part := range i.KeyParts() {
+		if err := part.Validate(); err != nil {
+			return err
+		}
+
+		if part.Expr != "" && i.Primary {
+			return errors.New("primary key cannot have expression key part")
+		}
+
+		if (i.Spatial || i.Fulltext) && (part.Expr != "" || part.Length != 0 || part.Order != "") {
+			return errors.New("spatial or fulltext index cannot have key part options")
+		}
+	}
+
 	return nil
 }
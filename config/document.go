@@ -0,0 +1,135 @@
+/*
+Package config ...
+
+Copyright © 2019 hajime-terasawa <terako.studio@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	defaultDocumentMaxItems  = 3
+	defaultDocumentMaxLength = 10
+	defaultDocumentMaxNumber = 1000
+)
+
+// Document represents a schema of JSON document generated for json column.
+// Fields are listed instead of mapped, because keys of yaml map are lower-cased on loading.
+type Document struct {
+	Name     string
+	Type     string
+	Optional bool
+	Fields   []*Document
+	Items    *Document
+	MinItems int
+	MaxItems int
+	// Min and Max are the range of length of string, or the range of number, nil means unset.
+	Min    *float64
+	Max    *float64
+	Values []interface{}
+}
+
+// CompleteWithDefault complete config value which is not required but configurable.
+func (d *Document) CompleteWithDefault() {
+	switch d.Type {
+	case "array":
+		if d.MaxItems == 0 {
+			d.MaxItems = max(defaultDocumentMaxItems, d.MinItems)
+		}
+	case "string":
+		d.completeRange(defaultDocumentMaxLength)
+	case "int", "float":
+		d.completeRange(defaultDocumentMaxNumber)
+	default:
+	}
+
+	for _, field := range d.Fields {
+		field.CompleteWithDefault()
+	}
+
+	if d.Items != nil {
+		d.Items.CompleteWithDefault()
+	}
+}
+
+// completeRange fills the unset bound, so that the range always holds the set one and an integer.
+func (d *Document) completeRange(defaultMax float64) {
+	if d.Min == nil {
+		lower := min(0, defaultMax)
+		if d.Max != nil {
+			lower = min(lower, math.Floor(*d.Max))
+		}
+
+		d.Min = &lower
+	}
+
+	if d.Max == nil {
+		upper := max(defaultMax, math.Ceil(*d.Min))
+		d.Max = &upper
+	}
+}
+
+// Validate validates document config.
+func (d *Document) Validate() error {
+	switch d.Type {
+	case "object":
+		if len(d.Fields) == 0 {
+			return errors.New("object document requires fields")
+		}
+
+		for _, field := range d.Fields {
+			if field.Name == "" {
+				return errors.New("field of object document requires name")
+			}
+
+			if err := field.Validate(); err != nil {
+				return err
+			}
+		}
+	case "array":
+		if d.Items == nil {
+			return errors.New("array document requires items")
+		}
+
+		if d.MinItems < 0 || (d.MaxItems > 0 && d.MinItems > d.MaxItems) {
+			return errors.New("minItems of array document must be between 0 and maxItems")
+		}
+
+		if err := d.Items.Validate(); err != nil {
+			return err
+		}
+	case "string", "int", "float":
+		if d.Min != nil && d.Max != nil && *d.Min > *d.Max {
+			return fmt.Errorf("min of %s document must not exceed max", d.Type)
+		}
+
+		if d.Type == "int" && d.Min != nil && d.Max != nil && math.Ceil(*d.Min) > math.Floor(*d.Max) {
+			return errors.New("range of int document must hold an integer")
+		}
+
+		if d.Type == "string" && ((d.Min != nil && *d.Min < 0) || (d.Max != nil && *d.Max < 0)) {
+			return errors.New("min and max of string document must be positive")
+		}
+	case "boolean", "date", "datetime", "null":
+	default:
+		return fmt.Errorf("document type %q is invalid or non-supported", d.Type)
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+/*
+Package config ...
+
+Copyright © 2019 hajime-terasawa <terako.studio@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/terakoya76/populator/config"
+)
+
+func Test_Document_CompleteWithDefault(t *testing.T) {
+	cases := []struct {
+		name string
+		doc  *config.Document
+		min  float64
+		max  float64
+	}{
+		{
+			name: "unset",
+			doc:  &config.Document{Type: "int"},
+			min:  0,
+			max:  1000,
+		},
+		{
+			name: "max of zero",
+			doc:  &config.Document{Type: "int", Min: float64Ptr(-10), Max: float64Ptr(0)},
+			min:  -10,
+			max:  0,
+		},
+		{
+			name: "min only over the default max",
+			doc:  &config.Document{Type: "int", Min: float64Ptr(5000)},
+			min:  5000,
+			max:  5000,
+		},
+		{
+			name: "negative max only",
+			doc:  &config.Document{Type: "float", Max: float64Ptr(-5)},
+			min:  -5,
+			max:  -5,
+		},
+		{
+			name: "string w/ min only",
+			doc:  &config.Document{Type: "string", Min: float64Ptr(5)},
+			min:  5,
+			max:  10,
+		},
+		{
+			name: "fractional min only",
+			doc:  &config.Document{Type: "int", Min: float64Ptr(1000.5)},
+			min:  1000.5,
+			max:  1001,
+		},
+	}
+
+	for _, c := range cases {
+		c.doc.CompleteWithDefault()
+
+		if !assert.NoError(t, c.doc.Validate()) ||
+			!assert.Equal(t, c.min, *c.doc.Min) ||
+			!assert.Equal(t, c.max, *c.doc.Max) {
+			t.Errorf("case: %s is failed\n", c.name)
+		}
+	}
+}
+
+func Test_Document_Validate(t *testing.T) {
+	cases := []struct {
+		name string
+		doc  *config.Document
+		err  bool
+	}{
+		{
+			name: "min exceeds max",
+			doc:  &config.Document{Type: "int", Min: float64Ptr(5), Max: float64Ptr(0)},
+			err:  true,
+		},
+		{
+			name: "negative length of string",
+			doc:  &config.Document{Type: "string", Min: float64Ptr(-1)},
+			err:  true,
+		},
+		{
+			name: "max of zero",
+			doc:  &config.Document{Type: "string", Max: float64Ptr(0)},
+			err:  false,
+		},
+		{
+			name: "range of int w/o integer",
+			doc:  &config.Document{Type: "int", Min: float64Ptr(1.2), Max: float64Ptr(1.8)},
+			err:  true,
+		},
+		{
+			name: "range of float w/o integer",
+			doc:  &config.Document{Type: "float", Min: float64Ptr(1.2), Max: float64Ptr(1.8)},
+			err:  false,
+		},
+		{
+			name: "minItems w/o maxItems",
+			doc:  &config.Document{Type: "array", MinItems: 5, Items: &config.Document{Type: "int"}},
+			err:  false,
+		},
+	}
+
+	for _, c := range cases {
+		if !assert.Equal(t, c.err, c.doc.Validate() != nil) {
+			t.Errorf("case: %s is failed\n", c.name)
+		}
+	}
+}
+
+func Test_Document_CompleteWithDefault_Items(t *testing.T) {
+	cases := []struct {
+		name     string
+		doc      *config.Document
+		minItems int
+		maxItems int
+	}{
+		{
+			name:     "unset",
+			doc:      &config.Document{Type: "array", Items: &config.Document{Type: "int"}},
+			minItems: 0,
+			maxItems: 3,
+		},
+		{
+			name:     "minItems only over the default maxItems",
+			doc:      &config.Document{Type: "array", MinItems: 5, Items: &config.Document{Type: "int"}},
+			minItems: 5,
+			maxItems: 5,
+		},
+		{
+			name:     "maxItems",
+			doc:      &config.Document{Type: "array", MinItems: 1, MaxItems: 10, Items: &config.Document{Type: "int"}},
+			minItems: 1,
+			maxItems: 10,
+		},
+	}
+
+	for _, c := range cases {
+		c.doc.CompleteWithDefault()
+
+		if !assert.NoError(t, c.doc.Validate()) ||
+			!assert.Equal(t, c.minItems, c.doc.MinItems) ||
+			!assert.Equal(t, c.maxItems, c.doc.MaxItems) {
+			t.Errorf("case: %s is failed\n", c.name)
+		}
+	}
+}
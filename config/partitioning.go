@@ -0,0 +1,286 @@
+/*
+Package config ...
+
+Copyright © 2019 hajime-terasawa <terako.studio@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// MaxValue is the bound of the last range partition.
+const MaxValue = "MAXVALUE"
+
+const dateLayout = "2006-01-02"
+
+// Partitioning represents a PARTITION BY clause of a table.
+type Partitioning struct {
+	// Type is one of range, list, hash and key.
+	Type string
+	// Expr is a column or an expression partitioning records by range, list and hash.
+	Expr string
+	// Columns are partitioning key columns of key partitioning, empty means the primary key.
+	Columns []string
+	// Count is the number of partitions of hash and key partitioning.
+	Count      int
+	Partitions []*Partition
+	// Interval generates range partitions from From to To by day, month or year.
+	Interval string
+	From     string
+	To       string
+	MaxValue bool
+	// Weights are the ratio of records on each partition, paired w/ partitions in order.
+	Weights []float64
+
+	pool *ValuePool
+}
+
+// Partition represents a single partition of range or list partitioning.
+type Partition struct {
+	Name     string
+	LessThan interface{}
+	In       []interface{}
+	Weight   float64
+}
+
+var plainColumnRe = regexp.MustCompile("^`?[A-Za-z0-9_$]+`?$")
+
+// Column returns the partitioning column when the expr is a plain column.
+func (p *Partitioning) Column() string {
+	if !plainColumnRe.MatchString(strings.TrimSpace(p.Expr)) {
+		return ""
+	}
+
+	return strings.Trim(strings.TrimSpace(p.Expr), "`")
+}
+
+// CompleteWithDefault complete config value which is not required but configurable.
+func (p *Partitioning) CompleteWithDefault() {
+	p.Type = strings.ToLower(p.Type)
+
+	if p.Interval != "" && len(p.Partitions) == 0 {
+		// already validated
+		partitions, _ := p.intervalPartitions()
+		p.Partitions = partitions
+	}
+
+	for i, partition := range p.Partitions {
+		if partition.Name == "" {
+			partition.Name = fmt.Sprintf("p%d", i)
+		}
+
+		if i < len(p.Weights) {
+			partition.Weight = p.Weights[i]
+		}
+
+		// yaml timestamp may be decoded as time.Time
+		if t, ok := partition.LessThan.(time.Time); ok {
+			partition.LessThan = t.Format(dateLayout)
+		}
+	}
+
+	p.pool = p.buildPool()
+}
+
+func (p *Partitioning) buildPool() *ValuePool {
+	indexes := make([]interface{}, len(p.Partitions))
+	weights := make([]float64, len(p.Partitions))
+
+	// partitions are equally weighted w/o weights, so that records always land in a declared partition
+	weighted := p.Weighted()
+	for i, partition := range p.Partitions {
+		indexes[i] = i
+		weights[i] = 1
+		if weighted {
+			weights[i] = partition.Weight
+		}
+	}
+
+	pool, err := NewValuePool(indexes, weights)
+	if err != nil {
+		return nil
+	}
+
+	return pool
+}
+
+// PickPartition returns the index of a partition picked by weights.
+func (p *Partitioning) PickPartition() int {
+	pool := p.pool
+	if pool == nil {
+		pool = p.buildPool()
+	}
+
+	if pool == nil {
+		return 0
+	}
+
+	idx, _ := pool.Pick().(int)
+
+	return idx
+}
+
+// intervalPartitions generates range partitions by the interval.
+func (p *Partitioning) intervalPartitions() ([]*Partition, error) {
+	from, err := time.Parse(dateLayout, p.From)
+	if err != nil {
+		return nil, fmt.Errorf("from of partitioning is invalid: %w", err)
+	}
+
+	to, err := time.Parse(dateLayout, p.To)
+	if err != nil {
+		return nil, fmt.Errorf("to of partitioning is invalid: %w", err)
+	}
+
+	if !from.Before(to) {
+		return nil, errors.New("from of partitioning must be before to")
+	}
+
+	var (
+		next   func(time.Time) time.Time
+		format string
+	)
+
+	switch strings.ToLower(p.Interval) {
+	case "day":
+		next = func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }
+		format = "p20060102"
+	case "month":
+		next = func(t time.Time) time.Time { return t.AddDate(0, 1, 0) }
+		format = "p200601"
+	case "year":
+		next = func(t time.Time) time.Time { return t.AddDate(1, 0, 0) }
+		format = "p2006"
+	default:
+		return nil, fmt.Errorf("interval %q is invalid or non-supported", p.Interval)
+	}
+
+	partitions := make([]*Partition, 0)
+	for t := from; t.Before(to); t = next(t) {
+		partitions = append(partitions, &Partition{
+			Name:     t.Format(format),
+			LessThan: next(t).Format(dateLayout),
+		})
+	}
+
+	if p.MaxValue {
+		partitions = append(partitions, &Partition{
+			Name:     "pmax",
+			LessThan: MaxValue,
+		})
+	}
+
+	return partitions, nil
+}
+
+// Validate validates partitioning config.
+func (p *Partitioning) Validate() error {
+	partitions := p.Partitions
+	typ := strings.ToLower(p.Type)
+
+	switch typ {
+	case "range":
+		if p.Expr == "" {
+			return errors.New("range partitioning requires expr")
+		}
+
+		if p.Interval != "" {
+			if len(p.Partitions) > 0 {
+				return errors.New("both of interval and partitions cannot be set")
+			}
+
+			generated, err := p.intervalPartitions()
+			if err != nil {
+				return err
+			}
+
+			partitions = generated
+		}
+
+		if len(partitions) == 0 {
+			return errors.New("range partitioning requires partitions or interval")
+		}
+
+		for _, partition := range partitions {
+			if partition.LessThan == nil {
+				return errors.New("range partition requires lessThan")
+			}
+		}
+	case "list":
+		if p.Expr == "" {
+			return errors.New("list partitioning requires expr")
+		}
+
+		if len(partitions) == 0 {
+			return errors.New("list partitioning requires partitions")
+		}
+
+		for _, partition := range partitions {
+			if len(partition.In) == 0 {
+				return errors.New("list partition requires in")
+			}
+		}
+	case "hash", "key":
+		if typ == "hash" && p.Expr == "" {
+			return errors.New("hash partitioning requires expr")
+		}
+
+		if p.Count <= 0 {
+			return fmt.Errorf("%s partitioning requires count", typ)
+		}
+
+		if len(p.Weights) > 0 {
+			return fmt.Errorf("weights are not available for %s partitioning", typ)
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("partitioning type %q is invalid or non-supported", p.Type)
+	}
+
+	for _, partition := range partitions {
+		if err := validateIdentifier("partition", partition.Name); err != nil {
+			return err
+		}
+	}
+
+	if len(p.Weights) > 0 && len(p.Weights) != len(partitions) {
+		return errors.New("the number of weights must match the number of partitions")
+	}
+
+	for _, w := range p.Weights {
+		if w < 0 {
+			return errors.New("weight must not be negative")
+		}
+	}
+
+	return nil
+}
+
+// Weighted returns whether records are spread across partitions by weights.
+func (p *Partitioning) Weighted() bool {
+	for _, partition := range p.Partitions {
+		if partition.Weight > 0 {
+			return true
+		}
+	}
+
+	return false
+}
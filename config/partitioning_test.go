@@ -0,0 +1,210 @@
+/*
+Package config ...
+
+Copyright © 2019 hajime-terasawa <terako.studio@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/terakoya76/populator/config"
+)
+
+func Test_Partitioning_CompleteWithDefault(t *testing.T) {
+	p := &config.Partitioning{
+		Type:     "RANGE",
+		Expr:     "created_at",
+		Interval: "month",
+		From:     "2024-11-01",
+		To:       "2025-02-01",
+		MaxValue: true,
+		Weights:  []float64{0, 1, 4, 0},
+	}
+
+	if !assert.NoError(t, p.Validate()) {
+		return
+	}
+
+	p.CompleteWithDefault()
+
+	assert.Equal(t, []*config.Partition{
+		{Name: "p202411", LessThan: "2024-12-01", Weight: 0},
+		{Name: "p202412", LessThan: "2025-01-01", Weight: 1},
+		{Name: "p202501", LessThan: "2025-02-01", Weight: 4},
+		{Name: "pmax", LessThan: config.MaxValue, Weight: 0},
+	}, p.Partitions)
+
+	counts := make([]int, len(p.Partitions))
+	for i := 0; i < 5000; i++ {
+		counts[p.PickPartition()]++
+	}
+
+	assert.Equal(t, 0, counts[0])
+	assert.Equal(t, 0, counts[3])
+	assert.InDelta(t, 0.8, float64(counts[2])/5000, 0.05)
+}
+
+func Test_Partitioning_Validate(t *testing.T) {
+	cases := []struct {
+		name string
+		cfg  *config.Partitioning
+		err  error
+	}{
+		{
+			name: "unmatched weights",
+			cfg: &config.Partitioning{
+				Type:     "range",
+				Expr:     "created_at",
+				Interval: "year",
+				From:     "2023-01-01",
+				To:       "2025-01-01",
+				Weights:  []float64{1},
+			},
+			err: errors.New("the number of weights must match the number of partitions"),
+		},
+
+		{
+			name: "weighted hash",
+			cfg: &config.Partitioning{
+				Type:    "hash",
+				Expr:    "id",
+				Count:   2,
+				Weights: []float64{1, 2},
+			},
+			err: errors.New("weights are not available for hash partitioning"),
+		},
+
+		{
+			name: "list w/o values",
+			cfg: &config.Partitioning{
+				Type: "list",
+				Expr: "region",
+				Partitions: []*config.Partition{
+					{Name: "p0"},
+				},
+			},
+			err: errors.New("list partition requires in"),
+		},
+	}
+
+	for _, c := range cases {
+		err := c.cfg.Validate()
+		if !assert.Equal(t, c.err, err) {
+			t.Errorf("case: %s is failed, expected: %s, actual: %s\n", c.name, c.err, err)
+		}
+	}
+}
+
+func Test_Table_Validate_Partitioning(t *testing.T) {
+	cases := []struct {
+		name    string
+		columns []*config.Column
+		cfg     *config.Partitioning
+		err     error
+	}{
+		{
+			name:    "list over timestamp column",
+			columns: []*config.Column{{Name: "created_at", Type: "timestamp"}},
+			cfg: &config.Partitioning{
+				Type:       "list",
+				Expr:       "created_at",
+				Partitions: []*config.Partition{{Name: "p0", In: []interface{}{"2024-01-01"}}},
+			},
+			err: errors.New("list partitioning over timestamp column created_at is non-supported"),
+		},
+
+		{
+			name:    "range over timestamp column",
+			columns: []*config.Column{{Name: "created_at", Type: "timestamp"}},
+			cfg: &config.Partitioning{
+				Type:       "range",
+				Expr:       "created_at",
+				Partitions: []*config.Partition{{Name: "p0", LessThan: "2024-01-01"}, {Name: "p1", LessThan: "MAXVALUE"}},
+				Weights:    []float64{1, 3},
+			},
+			err: nil,
+		},
+
+		{
+			name:    "weights over expression",
+			columns: []*config.Column{{Name: "created_at", Type: "date"}},
+			cfg: &config.Partitioning{
+				Type:       "range",
+				Expr:       "YEAR(created_at)",
+				Partitions: []*config.Partition{{Name: "p0", LessThan: 2024}},
+				Weights:    []float64{1},
+			},
+			err: errors.New("weights of partitioning require a column as expr"),
+		},
+
+		{
+			name:    "weights over auto_increment column",
+			columns: []*config.Column{{Name: "id", Type: "bigint", AutoIncrement: true}},
+			cfg: &config.Partitioning{
+				Type:       "range",
+				Expr:       "id",
+				Partitions: []*config.Partition{{Name: "p0", LessThan: 1000}},
+				Weights:    []float64{1},
+			},
+			err: errors.New("weights of partitioning are not available on auto_increment column id"),
+		},
+
+		{
+			name:    "weights over non-supported column type",
+			columns: []*config.Column{{Name: "code", Type: "varchar"}},
+			cfg: &config.Partitioning{
+				Type:       "range",
+				Expr:       "code",
+				Partitions: []*config.Partition{{Name: "p0", LessThan: "m"}},
+				Weights:    []float64{1},
+			},
+			err: errors.New("weights of range partitioning are not available on varchar column code"),
+		},
+	}
+
+	for _, c := range cases {
+		table := &config.Table{Name: "table_a", Columns: c.columns, Partitioning: c.cfg}
+
+		err := table.Validate()
+		if !assert.Equal(t, c.err, err) {
+			t.Errorf("case: %s is failed, expected: %s, actual: %s\n", c.name, c.err, err)
+		}
+	}
+}
+
+func Test_Partitioning_PickPartition_Unweighted(t *testing.T) {
+	p := &config.Partitioning{
+		Type: "list",
+		Expr: "region",
+		Partitions: []*config.Partition{
+			{Name: "p_east", In: []interface{}{"tokyo"}},
+			{Name: "p_west", In: []interface{}{"osaka"}},
+		},
+	}
+
+	p.CompleteWithDefault()
+
+	counts := make([]int, len(p.Partitions))
+	for i := 0; i < 5000; i++ {
+		counts[p.PickPartition()]++
+	}
+
+	assert.InDelta(t, 0.5, float64(counts[0])/5000, 0.05)
+	assert.InDelta(t, 0.5, float64(counts[1])/5000, 0.05)
+}
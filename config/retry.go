@@ -0,0 +1,66 @@
+/*
+Package config ...
+
+Copyright © 2019 hajime-terasawa <terako.studio@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+// Retry represents how batches failed w/ transient errors are retried.
+type Retry struct {
+	// MaxAttempts is the max number of attempts of a batch including the first one.
+	MaxAttempts int
+	// Backoff is the base of exponential backoff, which is capped by MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+const (
+	defaultRetryMaxAttempts = 5
+	defaultRetryBackoff     = 100 * time.Millisecond
+	defaultRetryMaxBackoff  = 10 * time.Second
+)
+
+// CompleteWithDefault complete config value which is not required but configurable.
+func (r *Retry) CompleteWithDefault() {
+	if r.MaxAttempts == 0 {
+		r.MaxAttempts = defaultRetryMaxAttempts
+	}
+
+	if r.Backoff == 0 {
+		r.Backoff = defaultRetryBackoff
+	}
+
+	if r.MaxBackoff == 0 {
+		r.MaxBackoff = max(defaultRetryMaxBackoff, r.Backoff)
+	}
+}
+
+// Validate validates retry config.
+func (r *Retry) Validate() error {
+	if r.MaxAttempts < 0 || r.Backoff < 0 || r.MaxBackoff < 0 {
+		return errors.New("maxAttempts, backoff and maxBackoff of retry must be positive")
+	}
+
+	if r.MaxBackoff != 0 && r.Backoff > r.MaxBackoff {
+		return errors.New("backoff of retry must be less than or equal to maxBackoff")
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+/*
+Package config ...
+
+Copyright © 2019 hajime-terasawa <terako.studio@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/terakoya76/populator/utils"
+)
+
+// TableOptions represents table options following the column and index definitions.
+type TableOptions struct {
+	Engine        string
+	Collate       string
+	RowFormat     string
+	Compression   string
+	KeyBlockSize  int
+	Comment       string
+	AutoIncrement int
+}
+
+// engines maps accepted engine names to the canonical ones.
+var engines = map[string]string{
+	"innodb":  "InnoDB",
+	"myisam":  "MyISAM",
+	"rocksdb": "ROCKSDB",
+	"myrocks": "ROCKSDB",
+	"memory":  "MEMORY",
+}
+
+// rowFormats holds row formats supported by each engine.
+var rowFormats = map[string][]interface{}{
+	"InnoDB":  {"DEFAULT", "DYNAMIC", "COMPACT", "REDUNDANT", "COMPRESSED"},
+	"MyISAM":  {"DEFAULT", "FIXED", "DYNAMIC"},
+	"ROCKSDB": {},
+	"MEMORY":  {"DEFAULT", "FIXED"},
+}
+
+// CompleteWithDefault complete config value which is not required but configurable.
+func (o *TableOptions) CompleteWithDefault() {
+	if canonical, ok := engines[strings.ToLower(o.Engine)]; ok {
+		o.Engine = canonical
+	}
+
+	o.RowFormat = strings.ToUpper(o.RowFormat)
+	o.Compression = strings.ToLower(o.Compression)
+}
+
+// Validate validates table options config.
+func (o *TableOptions) Validate() error {
+	engine := "InnoDB"
+	if o.Engine != "" {
+		canonical, ok := engines[strings.ToLower(o.Engine)]
+		if !ok {
+			return fmt.Errorf("engine %q is invalid or non-supported", o.Engine)
+		}
+
+		engine = canonical
+	}
+
+	rowFormat := strings.ToUpper(o.RowFormat)
+	if rowFormat != "" && !utils.Contains(rowFormats[engine], rowFormat) {
+		return fmt.Errorf("row format %s is not supported by %s", rowFormat, engine)
+	}
+
+	if o.KeyBlockSize != 0 {
+		if err := validateKeyBlockSize(engine, rowFormat, o.KeyBlockSize); err != nil {
+			return err
+		}
+	}
+
+	if o.Compression != "" {
+		if engine != "InnoDB" {
+			return fmt.Errorf("compression is not supported by %s", engine)
+		}
+
+		switch strings.ToLower(o.Compression) {
+		case "zlib", "lz4", "none":
+		default:
+			return fmt.Errorf("compression %q is invalid or non-supported", o.Compression)
+		}
+	}
+
+	if o.AutoIncrement < 0 {
+		return errors.New("auto increment must not be negative")
+	}
+
+	return nil
+}
+
+func validateKeyBlockSize(engine, rowFormat string, size int) error {
+	switch engine {
+	case "InnoDB":
+		//nolint:mnd
+		switch size {
+		case 1, 2, 4, 8, 16:
+		default:
+			return errors.New("key block size of InnoDB must be one of 1, 2, 4, 8 and 16")
+		}
+
+		if rowFormat != "" && rowFormat != "COMPRESSED" {
+			return errors.New("key block size of InnoDB requires compressed row format")
+		}
+	case "MyISAM":
+		if size < 0 {
+			return errors.New("key block size must be positive")
+		}
+	default:
+		return fmt.Errorf("key block size is not supported by %s", engine)
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+/*
+Package config ...
+
+Copyright © 2019 hajime-terasawa <terako.studio@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Throttle represents thresholds of server health, populating pauses while any of them is exceeded.
+type Throttle struct {
+	// Interval is how often the thresholds are checked.
+	Interval time.Duration
+	// MaxThreadsRunning is the threshold of Threads_running status, zero means unchecked.
+	MaxThreadsRunning int
+	// Status is the thresholds of custom global status.
+	Status []*StatusThreshold
+	// Replicas are DSNs of replicas whose lag is checked, like user:password@tcp(host:3306)/.
+	Replicas []string
+	// MaxLag is the threshold of Seconds_Behind_Source of the replicas.
+	MaxLag time.Duration
+}
+
+// StatusThreshold represents the max value of a global status.
+type StatusThreshold struct {
+	Name string
+	Max  float64
+}
+
+const (
+	defaultThrottleInterval = time.Second
+	defaultThrottleMaxLag   = time.Second
+)
+
+// CompleteWithDefault complete config value which is not required but configurable.
+func (t *Throttle) CompleteWithDefault() {
+	if t.Interval == 0 {
+		t.Interval = defaultThrottleInterval
+	}
+
+	if len(t.Replicas) > 0 && t.MaxLag == 0 {
+		t.MaxLag = defaultThrottleMaxLag
+	}
+}
+
+// Validate validates throttle config.
+func (t *Throttle) Validate() error {
+	if t.Interval < 0 || t.MaxThreadsRunning < 0 || t.MaxLag < 0 {
+		return errors.New("interval, maxThreadsRunning and maxLag of throttle must be positive")
+	}
+
+	for _, s := range t.Status {
+		if s.Name == "" {
+			return errors.New("status of throttle must have name")
+		}
+
+		if s.Max < 0 {
+			return fmt.Errorf("max of status %s must be positive", s.Name)
+		}
+	}
+
+	for _, replica := range t.Replicas {
+		if replica == "" {
+			return errors.New("replicas of throttle must not be empty")
+		}
+	}
+
+	return nil
+}
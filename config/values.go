@@ -0,0 +1,263 @@
+/*
+Package config ...
+
+Copyright © 2019 hajime-terasawa <terako.studio@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ValuePool holds candidate values of a column w/ their weights.
+type ValuePool struct {
+	Values  []interface{}
+	Weights []float64
+
+	cumulative []float64
+}
+
+// NewValuePool builds ValuePool. When weights is empty, every value has the same weight.
+func NewValuePool(values []interface{}, weights []float64) (*ValuePool, error) {
+	if len(values) == 0 {
+		return nil, errors.New("value pool must have at least one value")
+	}
+
+	if len(weights) > 0 && len(weights) != len(values) {
+		return nil, errors.New("the number of weights must match the number of values")
+	}
+
+	pool := &ValuePool{
+		Values:     values,
+		Weights:    weights,
+		cumulative: make([]float64, len(values)),
+	}
+
+	var sum float64
+	for i := range values {
+		w := 1.0
+		if len(weights) > 0 {
+			w = weights[i]
+		}
+
+		if w < 0 {
+			return nil, errors.New("weight must not be negative")
+		}
+
+		sum += w
+		pool.cumulative[i] = sum
+	}
+
+	if sum == 0 {
+		return nil, errors.New("sum of weights must be positive")
+	}
+
+	return pool, nil
+}
+
+// Pick extracts a value from the pool according to the weights.
+func (p *ValuePool) Pick() interface{} {
+	total := p.cumulative[len(p.cumulative)-1]
+
+	//nolint:gosec
+	r := rand.Float64() * total
+
+	// zero weighted values share the cumulative weight w/ the previous one, so they are never picked.
+	idx := sort.Search(len(p.cumulative), func(i int) bool {
+		return p.cumulative[i] > r
+	})
+
+	return p.Values[idx]
+}
+
+// LoadValuePool reads a value pool from the given file.
+// The format is decided by the extension: .csv, .json, otherwise one value per line.
+func LoadValuePool(path string) (*ValuePool, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open values file %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var (
+		values  []interface{}
+		weights []float64
+	)
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		values, weights, err = readCSVValues(f)
+	case ".json":
+		values, err = readJSONValues(f)
+	default:
+		values, err = readTextValues(f)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read values file %s: %w", path, err)
+	}
+
+	pool, err := NewValuePool(values, weights)
+	if err != nil {
+		return nil, fmt.Errorf("values file %s is invalid: %w", path, err)
+	}
+
+	return pool, nil
+}
+
+func readTextValues(r io.Reader) ([]interface{}, error) {
+	values := make([]interface{}, 0)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+
+		values = append(values, line)
+	}
+
+	return values, scanner.Err()
+}
+
+// readCSVValues reads the 1st column as values and the optional 2nd column as weights.
+func readCSVValues(r io.Reader) ([]interface{}, []float64, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values := make([]interface{}, 0, len(records))
+	weights := make([]float64, 0, len(records))
+	weighted := false
+
+	for i, record := range records {
+		if len(record) == 0 || (len(record) == 1 && record[0] == "") {
+			continue
+		}
+
+		values = append(values, record[0])
+
+		if len(record) < 2 {
+			weights = append(weights, 1)
+			continue
+		}
+
+		w, err := strconv.ParseFloat(strings.TrimSpace(record[1]), 64)
+		if err != nil {
+			return nil, nil, fmt.Errorf("weight on line %d is not a number: %w", i+1, err)
+		}
+
+		weights = append(weights, w)
+		weighted = true
+	}
+
+	if !weighted {
+		return values, nil, nil
+	}
+
+	return values, weights, nil
+}
+
+func readJSONValues(r io.Reader) ([]interface{}, error) {
+	var values []interface{}
+	if err := json.NewDecoder(r).Decode(&values); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// LoadValuePools resolves values and valuesFile of every column into value pools, and loads vocabularies of words.
+// Files are relative to dir, and columns referencing the same valuesFile share the pool.
+func (c *config) LoadValuePools(dir string) error {
+	cache := make(map[string]*ValuePool)
+
+	for _, table := range c.Tables {
+		for _, column := range table.Columns {
+			if err := column.loadValuePool(dir, cache); err != nil {
+				return err
+			}
+
+			if err := column.loadWords(dir); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *Column) loadValuePool(dir string, cache map[string]*ValuePool) error {
+	if c.ValuesFile == "" {
+		if len(c.Values) == 0 {
+			return nil
+		}
+
+		pool, err := NewValuePool(c.Values, c.Weights)
+		if err != nil {
+			return fmt.Errorf("values of column %s is invalid: %w", c.Name, err)
+		}
+
+		c.pool = pool
+
+		return nil
+	}
+
+	path := c.ValuesFile
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+
+	if pool, ok := cache[path]; ok {
+		c.pool = pool
+		return nil
+	}
+
+	pool, err := LoadValuePool(path)
+	if err != nil {
+		return err
+	}
+
+	cache[path] = pool
+	c.pool = pool
+
+	return nil
+}
+
+// Pool returns the value pool of the column if it is loaded.
+func (c *Column) Pool() *ValuePool {
+	return c.pool
+}
+
+// SetPool replaces the value pool of the column.
+func (c *Column) SetPool(pool *ValuePool) {
+	c.pool = pool
+}
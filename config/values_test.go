@@ -0,0 +1,129 @@
+/*
+Package config ...
+
+Copyright © 2019 hajime-terasawa <terako.studio@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/terakoya76/populator/config"
+)
+
+func Test_LoadValuePool(t *testing.T) {
+	dir := t.TempDir()
+
+	cases := []struct {
+		name    string
+		file    string
+		content string
+		values  []interface{}
+		weights []float64
+		err     error
+	}{
+		{
+			name:    "text",
+			file:    "products.txt",
+			content: "apple\nbanana\n\ncherry\n",
+			values:  []interface{}{"apple", "banana", "cherry"},
+			weights: nil,
+			err:     nil,
+		},
+
+		{
+			name:    "csv",
+			file:    "products.csv",
+			content: "apple\nbanana\n",
+			values:  []interface{}{"apple", "banana"},
+			weights: nil,
+			err:     nil,
+		},
+
+		{
+			name:    "csv w/ weights",
+			file:    "weighted.csv",
+			content: "apple,10\nbanana\n\"cherry, dried\",0.5\n",
+			values:  []interface{}{"apple", "banana", "cherry, dried"},
+			weights: []float64{10, 1, 0.5},
+			err:     nil,
+		},
+
+		{
+			name:    "json",
+			file:    "products.json",
+			content: `["apple", 1, true]`,
+			values:  []interface{}{"apple", float64(1), true},
+			weights: nil,
+			err:     nil,
+		},
+
+		{
+			name:    "empty",
+			file:    "empty.txt",
+			content: "\n",
+			values:  nil,
+			weights: nil,
+			err:     errors.New("values file " + filepath.Join(dir, "empty.txt") + " is invalid: value pool must have at least one value"),
+		},
+	}
+
+	for _, c := range cases {
+		path := filepath.Join(dir, c.file)
+		if err := os.WriteFile(path, []byte(c.content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		pool, err := config.LoadValuePool(path)
+		if c.err != nil {
+			if !assert.EqualError(t, err, c.err.Error()) {
+				t.Errorf("case: %s is failed, expected: %s, actual: %s\n", c.name, c.err, err)
+			}
+
+			continue
+		}
+
+		if !assert.NoError(t, err) {
+			t.Errorf("case: %s is failed, err: %s\n", c.name, err)
+			continue
+		}
+
+		if !assert.Equal(t, c.values, pool.Values) || !assert.Equal(t, c.weights, pool.Weights) {
+			t.Errorf("case: %s is failed, expected: %+v %+v, actual: %+v %+v\n", c.name, c.values, c.weights, pool.Values, pool.Weights)
+		}
+	}
+}
+
+func Test_ValuePool_Pick(t *testing.T) {
+	pool, err := config.NewValuePool(
+		[]interface{}{"never", "always", "never too"},
+		[]float64{0, 1, 0},
+	)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	for i := 0; i < 1000; i++ {
+		if v := pool.Pick(); v != "always" {
+			t.Errorf("zero weighted value is picked: %v\n", v)
+			return
+		}
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Package config ...
+
+Copyright © 2019 hajime-terasawa <terako.studio@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/terakoya76/populator/rand"
+)
+
+const (
+	defaultVocabulary = 5000
+	defaultSkew       = 1.1
+	defaultMinWords   = 5
+	defaultMaxWords   = 30
+)
+
+// Words represents natural-language like text made of words w/ Zipfian frequencies.
+type Words struct {
+	// Vocabulary is the number of distinct words picked from the dictionary.
+	Vocabulary int
+	// File holds the vocabulary one per line, ordered from the most frequent word.
+	File string
+	// Skew is the exponent of Zipf's law, which must be greater than 1.
+	Skew float64
+	Min  int
+	Max  int
+}
+
+// CompleteWithDefault complete config value which is not required but configurable.
+func (w *Words) CompleteWithDefault() {
+	if w.Vocabulary == 0 {
+		w.Vocabulary = defaultVocabulary
+	}
+
+	if w.Skew == 0 {
+		w.Skew = defaultSkew
+	}
+
+	if w.Max == 0 {
+		if w.Min == 0 {
+			w.Min = defaultMinWords
+		}
+
+		w.Max = max(defaultMaxWords, w.Min)
+	}
+}
+
+// Validate validates words config.
+func (w *Words) Validate() error {
+	if w.Vocabulary < 0 {
+		return errors.New("vocabulary of words must be positive")
+	}
+
+	if w.Skew != 0 && w.Skew <= 1 {
+		return errors.New("skew of words must be greater than 1")
+	}
+
+	if w.Min < 0 || (w.Max > 0 && w.Min > w.Max) {
+		return errors.New("min of words must be between 0 and max")
+	}
+
+	return nil
+}
+
+// loadWords builds the text generator of the column from the dictionary or the vocabulary file.
+func (c *Column) loadWords(dir string) error {
+	if c.Words == nil {
+		return nil
+	}
+
+	var vocabulary []string
+
+	if c.Words.File != "" {
+		path := c.Words.File
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+
+		pool, err := LoadValuePool(path)
+		if err != nil {
+			return err
+		}
+
+		for _, v := range pool.Values {
+			vocabulary = append(vocabulary, fmt.Sprint(v))
+		}
+	} else {
+		vocabulary = rand.Vocabulary(c.Words.Vocabulary)
+	}
+
+	if len(vocabulary) == 0 {
+		return fmt.Errorf("vocabulary of column %s is empty", c.Name)
+	}
+
+	c.words = rand.NewWords(vocabulary, c.Words.Skew)
+
+	return nil
+}
+
+// WordsGenerator returns the text generator of the column if it is loaded.
+func (c *Column) WordsGenerator() *rand.Words {
+	return c.words
+}
@@ -0,0 +1,70 @@
+/*
+Package config ...
+
+Copyright © 2019 hajime-terasawa <terako.studio@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/terakoya76/populator/config"
+)
+
+func Test_Words_CompleteWithDefault(t *testing.T) {
+	cases := []struct {
+		name  string
+		words *config.Words
+		min   int
+		max   int
+	}{
+		{
+			name:  "unset",
+			words: &config.Words{},
+			min:   5,
+			max:   30,
+		},
+		{
+			name:  "min only",
+			words: &config.Words{Min: 10},
+			min:   10,
+			max:   30,
+		},
+		{
+			name:  "min only over the default max",
+			words: &config.Words{Min: 40},
+			min:   40,
+			max:   40,
+		},
+		{
+			name:  "max only",
+			words: &config.Words{Max: 3},
+			min:   0,
+			max:   3,
+		},
+	}
+
+	for _, c := range cases {
+		c.words.CompleteWithDefault()
+
+		if !assert.NoError(t, c.words.Validate()) ||
+			!assert.Equal(t, c.min, c.words.Min) ||
+			!assert.Equal(t, c.max, c.words.Max) {
+			t.Errorf("case: %s is failed\n", c.name)
+		}
+	}
+}
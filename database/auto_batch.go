@@ -0,0 +1,247 @@
+/*
+Package database ...
+
+Copyright © 2019 hajime-terasawa <terako.studio@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package database
+
+import (
+	"sync"
+	"time"
+
+	"github.com/terakoya76/populator/config"
+)
+
+// tuning phases of BatchTuner.
+const (
+	tuningSize = iota
+	tuningConcurrency
+	settled
+)
+
+// improvement is the ratio of throughput regarded as a gain, not a noise.
+const improvement = 1.05
+
+// BatchTuner adjusts batch size and concurrency within the bounds to maximize throughput.
+// Each of them is climbed up, and down if going up does not pay, then it settles.
+type BatchTuner struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+	cfg  *config.AutoBatch
+
+	size        int
+	concurrency int
+
+	phase     int
+	direction int
+	gained    bool
+	best      float64
+	bestValue int
+
+	// measurement of the current window
+	rows    int
+	batches int
+	latency time.Duration
+
+	stopped bool
+}
+
+// NewBatchTuner returns BatchTuner starting from the given batch size and concurrency.
+func NewBatchTuner(cfg *config.AutoBatch, size, concurrency int) *BatchTuner {
+	t := &BatchTuner{
+		cfg:         cfg,
+		size:        clamp(size, cfg.MinSize, cfg.MaxSize),
+		concurrency: clamp(concurrency, cfg.MinConcurrency, cfg.MaxConcurrency),
+		direction:   1,
+	}
+	t.cond = sync.NewCond(&t.mu)
+	t.bestValue = t.size
+
+	return t
+}
+
+func clamp(v, lower, upper int) int {
+	return min(max(v, lower), upper)
+}
+
+// BatchSize returns the current batch size.
+func (t *BatchTuner) BatchSize() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.size
+}
+
+// Concurrency returns the current number of active workers.
+func (t *BatchTuner) Concurrency() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.concurrency
+}
+
+// Settled returns whether the tuning is done.
+func (t *BatchTuner) Settled() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.phase == settled
+}
+
+// acquire blocks the worker while it is out of the current concurrency.
+func (t *BatchTuner) acquire(worker int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for worker >= t.concurrency && !t.stopped {
+		t.cond.Wait()
+	}
+}
+
+// stop releases all the blocked workers.
+func (t *BatchTuner) stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.stopped = true
+	t.cond.Broadcast()
+}
+
+// Observe records a batch of rows inserted in the latency, then adjusts the settings per window of batches.
+func (t *BatchTuner) Observe(rows int, latency time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.rows += rows
+	t.batches++
+	t.latency += latency
+
+	// a window spans a few rounds of the active workers
+	if t.batches < 2*t.concurrency {
+		return
+	}
+
+	avg := t.latency / time.Duration(t.batches)
+
+	// workers run in parallel, so the wall time is about the total latency divided by them
+	throughput := float64(t.rows) * float64(t.concurrency) / t.latency.Seconds()
+
+	t.rows, t.batches, t.latency = 0, 0, 0
+
+	if t.cfg.MaxLatency > 0 && avg > t.cfg.MaxLatency && t.size > t.cfg.MinSize {
+		t.size = max(t.size/2, t.cfg.MinSize)
+		t.logf("batch size is shrunk to %d, since a batch takes %s", t.size, avg)
+
+		return
+	}
+
+	if t.phase == settled {
+		return
+	}
+
+	t.climb(throughput)
+}
+
+func (t *BatchTuner) climb(throughput float64) {
+	if t.best == 0 || throughput > t.best*improvement {
+		if t.best != 0 {
+			t.gained = true
+		}
+
+		t.best = throughput
+		t.bestValue = t.value()
+
+		if !t.step() {
+			t.next()
+		}
+
+		return
+	}
+
+	// going up does not pay, so try going down from the start
+	if !t.gained && t.direction > 0 {
+		t.set(t.bestValue)
+		t.direction = -1
+
+		if t.step() {
+			return
+		}
+	}
+
+	t.set(t.bestValue)
+	t.next()
+}
+
+func (t *BatchTuner) value() int {
+	if t.phase == tuningSize {
+		return t.size
+	}
+
+	return t.concurrency
+}
+
+func (t *BatchTuner) set(v int) {
+	if t.phase == tuningSize {
+		t.size = v
+		return
+	}
+
+	t.concurrency = v
+	t.cond.Broadcast()
+}
+
+// step moves the setting toward the direction, then returns false when it reaches the bound.
+func (t *BatchTuner) step() bool {
+	var v int
+
+	if t.phase == tuningSize {
+		if t.direction > 0 {
+			v = clamp(t.size*2, t.cfg.MinSize, t.cfg.MaxSize)
+		} else {
+			v = clamp(t.size/2, t.cfg.MinSize, t.cfg.MaxSize)
+		}
+	} else {
+		v = clamp(t.concurrency+t.direction, t.cfg.MinConcurrency, t.cfg.MaxConcurrency)
+	}
+
+	if v == t.value() {
+		return false
+	}
+
+	t.set(v)
+
+	return true
+}
+
+// next moves to the next phase, whose measurement starts over.
+func (t *BatchTuner) next() {
+	t.phase++
+	t.direction = 1
+	t.gained = false
+	t.best = 0
+
+	if t.phase == settled {
+		t.logf("settled w/ batchSize %d and concurrency %d", t.size, t.concurrency)
+		return
+	}
+
+	t.bestValue = t.value()
+}
+
+func (t *BatchTuner) logf(format string, args ...interface{}) {
+	if Verbose {
+		printf("auto batch: "+format+"\n", args...)
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Package database ...
+
+Copyright © 2019 hajime-terasawa <terako.studio@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package database_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/terakoya76/populator/config"
+	"github.com/terakoya76/populator/database"
+)
+
+func Test_BatchTuner(t *testing.T) {
+	cfg := &config.AutoBatch{
+		MinSize:        10,
+		MaxSize:        10000,
+		MinConcurrency: 1,
+		MaxConcurrency: 8,
+		MaxLatency:     time.Second,
+	}
+
+	// a batch costs 5ms + 10µs per row, and the server is contended over 4 workers
+	latency := func(rows, concurrency int) time.Duration {
+		d := 5*time.Millisecond + time.Duration(rows)*10*time.Microsecond
+		if concurrency > 4 {
+			d *= time.Duration((concurrency - 3) * (concurrency - 3))
+		}
+
+		return d
+	}
+
+	tuner := database.NewBatchTuner(cfg, 100, 2)
+	for i := 0; i < 1000 && !tuner.Settled(); i++ {
+		tuner.Observe(tuner.BatchSize(), latency(tuner.BatchSize(), tuner.Concurrency()))
+	}
+
+	assert.True(t, tuner.Settled())
+	assert.Equal(t, 6400, tuner.BatchSize())
+	assert.Equal(t, 4, tuner.Concurrency())
+}
+
+func Test_BatchTuner_MaxLatency(t *testing.T) {
+	cfg := &config.AutoBatch{
+		MinSize:        10,
+		MaxSize:        10000,
+		MinConcurrency: 1,
+		MaxConcurrency: 1,
+		MaxLatency:     50 * time.Millisecond,
+	}
+
+	tuner := database.NewBatchTuner(cfg, 100, 1)
+	tuner.Observe(100, 100*time.Millisecond)
+	tuner.Observe(100, 100*time.Millisecond)
+
+	assert.Equal(t, 50, tuner.BatchSize())
+}
@@ -18,6 +18,7 @@ limitations under the License.
 package database
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sync"
@@ -27,9 +28,11 @@ import (
 
 // DBClient is an interface for DB Querying.
 type DBClient interface {
-	CreateTable(cfg *config.Table) error
-	DropTable(cfg *config.Table) error
-	Populate(cfg *config.Table) error
+	CreateTable(ctx context.Context, cfg *config.Table) error
+	DropTable(ctx context.Context, cfg *config.Table) error
+	DiffTable(ctx context.Context, cfg *config.Table) ([]string, error)
+	AlterTable(ctx context.Context, cfg *config.Table, specs []string) error
+	Populate(ctx context.Context, cfg *config.Table) (*Report, error)
 }
 
 var client DBClient
@@ -50,7 +53,7 @@ func initialize() {
 	var err error
 
 	cfg := config.Instance
-	client, err = BuildClient(cfg.Database)
+	client, err = BuildClient(cfg.Database, cfg.MaxConcurrency())
 
 	if err != nil {
 		fmt.Println(err)
@@ -58,14 +61,14 @@ func initialize() {
 }
 
 // BuildClient builds DBClient for abstraction.
-func BuildClient(cfg *config.Database) (DBClient, error) {
+func BuildClient(cfg *config.Database, concurrency int) (DBClient, error) {
 	switch cfg.Driver {
 	case "mysql":
 		if err := SetupMySQLDB(cfg); err != nil {
 			return nil, err
 		}
 
-		return BuildMySQLClient(cfg)
+		return BuildMySQLClient(cfg, concurrency)
 	default:
 		return nil, errors.New("not supported database driver")
 	}
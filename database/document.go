@@ -0,0 +1,113 @@
+/*
+Package database ...
+
+Copyright © 2019 hajime-terasawa <terako.studio@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package database
+
+import (
+	"encoding/json"
+	"math"
+
+	"github.com/brianvoe/gofakeit/v7"
+
+	"github.com/terakoya76/populator/config"
+	"github.com/terakoya76/populator/rand"
+	"github.com/terakoya76/populator/utils"
+)
+
+// GenerateJSON returns random JSON text following the given document schema.
+// When the schema is not given, an empty object is returned.
+func GenerateJSON(cfg *config.Document) string {
+	if cfg == nil {
+		return "{}"
+	}
+
+	b, err := json.Marshal(generateDocument(cfg))
+	if err != nil {
+		return "{}"
+	}
+
+	return string(b)
+}
+
+func generateDocument(cfg *config.Document) interface{} {
+	if len(cfg.Values) > 0 {
+		return utils.Shuffle(cfg.Values)
+	}
+
+	switch cfg.Type {
+	case "object":
+		obj := make(map[string]interface{}, len(cfg.Fields))
+		for _, field := range cfg.Fields {
+			if field.Optional && rand.Boolean() {
+				continue
+			}
+
+			obj[field.Name] = generateDocument(field)
+		}
+
+		return obj
+
+	case "array":
+		n := gofakeit.Number(cfg.MinItems, cfg.MaxItems)
+		arr := make([]interface{}, n)
+		for i := range arr {
+			arr[i] = generateDocument(cfg.Items)
+		}
+
+		return arr
+
+	case "string":
+		n := gofakeit.Number(int(documentMin(cfg)), int(documentMax(cfg)))
+		return rand.VarChar(n, rand.ASCII)
+
+	case "int":
+		return gofakeit.Number(int(math.Ceil(documentMin(cfg))), int(math.Floor(documentMax(cfg))))
+
+	case "float":
+		return gofakeit.Float64Range(documentMin(cfg), documentMax(cfg))
+
+	case "boolean":
+		return rand.Boolean()
+
+	case "date":
+		return rand.Date()
+
+	case "datetime":
+		return rand.DateTime()
+
+	default:
+		return nil
+	}
+}
+
+// documentMin returns min of the document, which is 0 when unset.
+func documentMin(cfg *config.Document) float64 {
+	if cfg.Min == nil {
+		return 0
+	}
+
+	return *cfg.Min
+}
+
+// documentMax returns max of the document, which is min when unset.
+func documentMax(cfg *config.Document) float64 {
+	if cfg.Max == nil {
+		return documentMin(cfg)
+	}
+
+	return *cfg.Max
+}
@@ -0,0 +1,76 @@
+/*
+Package database ...
+
+Copyright © 2019 hajime-terasawa <terako.studio@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package database_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/terakoya76/populator/config"
+	"github.com/terakoya76/populator/database"
+)
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
+func Test_GenerateJSON(t *testing.T) {
+	doc := &config.Document{
+		Type: "object",
+		Fields: []*config.Document{
+			{Name: "userId", Type: "int", Min: float64Ptr(1), Max: float64Ptr(100)},
+			{Name: "status", Type: "string", Values: []interface{}{"active", "banned"}},
+			{Name: "nickname", Type: "string", Min: float64Ptr(3), Max: float64Ptr(8), Optional: true},
+			{
+				Name:     "tags",
+				Type:     "array",
+				MinItems: 1,
+				MaxItems: 4,
+				Items:    &config.Document{Type: "string", Min: float64Ptr(1), Max: float64Ptr(5)},
+			},
+		},
+	}
+	doc.CompleteWithDefault()
+
+	if !assert.NoError(t, doc.Validate()) {
+		return
+	}
+
+	for i := 0; i < 100; i++ {
+		var obj map[string]interface{}
+		if err := json.Unmarshal([]byte(database.GenerateJSON(doc)), &obj); err != nil {
+			t.Errorf("generated document is not valid json: %s\n", err)
+			return
+		}
+
+		userID, ok := obj["userId"].(float64)
+		assert.True(t, ok && userID >= 1 && userID <= 100, "userId: %v", obj["userId"])
+		assert.Contains(t, []interface{}{"active", "banned"}, obj["status"])
+
+		if nickname, ok := obj["nickname"]; ok {
+			assert.True(t, len(nickname.(string)) >= 3 && len(nickname.(string)) <= 8, "nickname: %v", nickname)
+		}
+
+		tags, ok := obj["tags"].([]interface{})
+		assert.True(t, ok && len(tags) >= 1 && len(tags) <= 4, "tags: %v", obj["tags"])
+	}
+
+	assert.Equal(t, "{}", database.GenerateJSON(nil))
+}
@@ -0,0 +1,86 @@
+/*
+Package database ...
+
+Copyright © 2019 hajime-terasawa <terako.studio@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package database
+
+import (
+	"time"
+
+	"github.com/terakoya76/populator/config"
+)
+
+// SplitRows exposes splitRows for tests.
+var SplitRows = splitRows
+
+// SQLFunc returns a value embedded as a function call w/ the args bound to placeholders, for tests.
+func SQLFunc(name string, args ...interface{}) interface{} {
+	return sqlFunc{name: name, args: args}
+}
+
+// MaxBatchBytes exposes maxBatchBytes for tests.
+var MaxBatchBytes = maxBatchBytes
+
+// EstimateRowBytes exposes estimateRowBytes for tests.
+var EstimateRowBytes = estimateRowBytes
+
+// Batch cuts the rows into batches as Populate does, for tests.
+func Batch(rows [][]interface{}, size, limit int) [][][]interface{} {
+	b := &batcher{size: size, limit: limit}
+
+	var batches [][][]interface{}
+	for _, row := range rows {
+		batches = append(batches, b.add(row)...)
+	}
+
+	if rest := b.flush(); len(rest) > 0 {
+		batches = append(batches, rest)
+	}
+
+	return batches
+}
+
+// NewLimiterAt returns Limiter started at the time instead of now, for tests.
+func NewLimiterAt(rate, burst float64, start time.Time) *Limiter {
+	l := NewLimiter(rate, burst)
+	l.last = start
+
+	return l
+}
+
+// Reserve exposes reserve for tests.
+func (l *Limiter) Reserve(now time.Time, n int) time.Duration {
+	return l.reserve(now, n)
+}
+
+// ProgressMode exposes progressMode for tests.
+var ProgressMode = progressMode
+
+// GenerateValue exposes generateValue for tests.
+func (db *MySQLClient) GenerateValue(cfg *config.Column) interface{} {
+	return db.generateValue(cfg)
+}
+
+// RangeValue exposes rangeValue for tests.
+var RangeValue = rangeValue
+
+// GeneratePartitionValue exposes generatePartitionValue for tests.
+func (db *MySQLClient) GeneratePartitionValue(cfg *config.Table) (string, interface{}, bool) {
+	return db.generatePartitionValue(cfg)
+}
+
+// ShouldRetry exposes shouldRetry for tests.
+var ShouldRetry = shouldRetry
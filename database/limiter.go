@@ -0,0 +1,75 @@
+/*
+Package database ...
+
+Copyright © 2019 hajime-terasawa <terako.studio@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package database
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RowLimiter limits rows per second inserted across all workers and tables, nil means unlimited.
+var RowLimiter *Limiter
+
+// QueryLimiter limits statements per second executed across all workers and tables, nil means unlimited.
+var QueryLimiter *Limiter
+
+// Limiter is a token bucket shared by goroutines, which allows bursts up to its capacity.
+type Limiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewLimiter returns Limiter of the rate per second, whose bucket is full at first.
+func NewLimiter(rate, burst float64) *Limiter {
+	return &Limiter{
+		rate:   rate,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until n tokens are taken, or returns the error of ctx when it is canceled meanwhile.
+// More tokens than the burst are lent, and the following callers wait for the debt.
+func (l *Limiter) Wait(ctx context.Context, n int) error {
+	if l == nil || n <= 0 {
+		return nil
+	}
+
+	return sleep(ctx, l.reserve(time.Now(), n))
+}
+
+// reserve takes n tokens at now, then returns the wait until the debt is paid.
+func (l *Limiter) reserve(now time.Time, n int) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.tokens = min(l.tokens+now.Sub(l.last).Seconds()*l.rate, l.burst)
+	l.last = now
+	l.tokens -= float64(n)
+
+	if l.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
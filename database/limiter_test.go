@@ -0,0 +1,123 @@
+/*
+Package database ...
+
+Copyright © 2019 hajime-terasawa <terako.studio@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/terakoya76/populator/database"
+)
+
+func Test_Limiter(t *testing.T) {
+	type reservation struct {
+		at   time.Duration
+		n    int
+		wait time.Duration
+	}
+
+	cases := []struct {
+		name         string
+		rate         float64
+		burst        float64
+		reservations []reservation
+	}{
+		{
+			name:  "within burst",
+			rate:  100,
+			burst: 100,
+			reservations: []reservation{
+				{at: 0, n: 50, wait: 0},
+				{at: 0, n: 50, wait: 0},
+			},
+		},
+		{
+			name:  "over burst",
+			rate:  1000,
+			burst: 100,
+			reservations: []reservation{
+				{at: 0, n: 100, wait: 0},
+				{at: 0, n: 100, wait: 100 * time.Millisecond},
+			},
+		},
+		{
+			name:  "debt is paid by the following callers",
+			rate:  1000,
+			burst: 100,
+			reservations: []reservation{
+				{at: 0, n: 100, wait: 0},
+				{at: 0, n: 100, wait: 100 * time.Millisecond},
+				{at: 0, n: 50, wait: 150 * time.Millisecond},
+				{at: 100 * time.Millisecond, n: 10, wait: 60 * time.Millisecond},
+			},
+		},
+		{
+			name:  "more tokens than burst are lent",
+			rate:  10,
+			burst: 10,
+			reservations: []reservation{
+				{at: 0, n: 30, wait: 2 * time.Second},
+			},
+		},
+		{
+			name:  "refilled up to burst",
+			rate:  1000,
+			burst: 100,
+			reservations: []reservation{
+				{at: 0, n: 100, wait: 0},
+				{at: time.Second, n: 150, wait: 50 * time.Millisecond},
+			},
+		},
+	}
+
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, c := range cases {
+		limiter := database.NewLimiterAt(c.rate, c.burst, start)
+
+		for _, r := range c.reservations {
+			wait := limiter.Reserve(start.Add(r.at), r.n)
+			if !assert.InDelta(t, r.wait, wait, float64(time.Microsecond)) {
+				t.Errorf("case: %s is failed\n", c.name)
+			}
+		}
+	}
+}
+
+func Test_Limiter_Nil(t *testing.T) {
+	var limiter *database.Limiter
+
+	start := time.Now()
+	assert.NoError(t, limiter.Wait(context.Background(), 1000))
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func Test_Limiter_Canceled(t *testing.T) {
+	limiter := database.NewLimiter(1, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// the debt takes 10s, which is interrupted by ctx
+	start := time.Now()
+	assert.ErrorIs(t, limiter.Wait(ctx, 11), context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
@@ -18,13 +18,20 @@ limitations under the License.
 package database
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math"
+	"net/url"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	// MySQL Driver.
 	_ "github.com/go-sql-driver/mysql"
 
+	"github.com/brianvoe/gofakeit/v7"
 	"github.com/jmoiron/sqlx"
 
 	"github.com/terakoya76/populator/config"
@@ -43,9 +50,12 @@ const (
 	longTextSize   int = 5000
 )
 
-// MaxConnections holds max_connections var for memory use control.
+// MaxConnections holds max_connections var of the server.
 var MaxConnections int
 
+// MaxAllowedPacket holds max_allowed_packet var of the server, which limits the size of a statement.
+var MaxAllowedPacket int
+
 // MySQLClient is an implementation of DBClient for MySQL.
 type MySQLClient struct {
 	*sqlx.DB
@@ -61,7 +71,7 @@ func SetupMySQLDB(cfg *config.Database) error {
 	}
 	defer db.Close()
 
-	_, err = db.Exec("CREATE DATABASE IF NOT EXISTS " + cfg.Name)
+	_, err = db.Exec("CREATE DATABASE IF NOT EXISTS " + quoteIdentifier(cfg.Name))
 	if err != nil {
 		return fmt.Errorf("failed to setup database %s on mysql: %+v", cfg.Name, err)
 	}
@@ -71,10 +81,10 @@ func SetupMySQLDB(cfg *config.Database) error {
 	return nil
 }
 
-// BuildMySQLClient returns MySQLClient.
-func BuildMySQLClient(cfg *config.Database) (*MySQLClient, error) {
+// BuildMySQLClient returns MySQLClient, whose connection pool is limited by the max concurrency of tables.
+func BuildMySQLClient(cfg *config.Database, concurrency int) (*MySQLClient, error) {
 	ci := buildConnectInfo(cfg)
-	db, err := sqlx.Connect("mysql", ci+cfg.Name)
+	db, err := sqlx.Connect("mysql", ci+url.PathEscape(cfg.Name))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to setup database %s on mysql: %+v", cfg.Name, err)
@@ -87,6 +97,20 @@ func BuildMySQLClient(cfg *config.Database) (*MySQLClient, error) {
 		fmt.Println(err)
 	}
 
+	err = db.QueryRow("show variables like \"max_allowed_packet\"").Scan(&_name, &MaxAllowedPacket)
+
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	if MaxConnections > 0 && concurrency >= MaxConnections {
+		fmt.Printf("concurrency %d reaches max_connections %d of the server\n", concurrency, MaxConnections)
+	}
+
+	// each worker holds a connection, the others are kept for queries like valuesQuery
+	db.SetMaxOpenConns(concurrency + 1)
+	db.SetMaxIdleConns(concurrency + 1)
+
 	return &MySQLClient{db}, nil
 }
 
@@ -144,6 +168,12 @@ var PrecisionRequiredDataTypes = []interface{}{
 	"double",
 }
 
+// MemberRequiredDataTypes require DataType('member', ...) like sql.
+var MemberRequiredDataTypes = []interface{}{
+	"enum",
+	"set",
+}
+
 // ProhibitDefaultDataTypes must allow null value.
 var ProhibitDefaultDataTypes = []interface{}{
 	"tinyblob",
@@ -154,17 +184,31 @@ var ProhibitDefaultDataTypes = []interface{}{
 	"mediumtext",
 	"longblob",
 	"longtext",
+	"json",
+	"geometry",
+	"point",
+	"linestring",
+	"polygon",
+}
+
+// maxPlaceholders is the limit of placeholders in a prepared statement of MySQL.
+const maxPlaceholders = 65535
+
+// sqlFunc is a value embedded into sql as a function call, whose args are bound to placeholders.
+type sqlFunc struct {
+	name string
+	args []interface{}
 }
 
 // CreateTable does CreateTable statement for MySQL.
-func (db *MySQLClient) CreateTable(cfg *config.Table) error {
+func (db *MySQLClient) CreateTable(ctx context.Context, cfg *config.Table) error {
 	sql := db.BuildCreateTableStmt(cfg)
 
 	if Verbose {
 		fmt.Println(sql)
 	}
 
-	if _, err := db.Exec(sql); err != nil {
+	if _, err := db.ExecContext(ctx, sql); err != nil {
 		return err
 	}
 
@@ -178,7 +222,7 @@ func (db *MySQLClient) BuildCreateTableStmt(cfg *config.Table) string {
 	sb.WriteString(
 		fmt.Sprintf(
 			"CREATE TABLE IF NOT EXISTS %s (\n",
-			cfg.Name,
+			quoteIdentifier(cfg.Name),
 		),
 	)
 
@@ -200,6 +244,11 @@ func (db *MySQLClient) BuildCreateTableStmt(cfg *config.Table) string {
 
 	sb.WriteString(strings.Join(regIdx, ",\n"))
 
+	for _, check := range cfg.Checks {
+		sb.WriteString(",\n")
+		sb.WriteString(db.BuildCheckDesc(check))
+	}
+
 	sb.WriteString(
 		fmt.Sprintf(
 			"\n) DEFAULT CHARSET=%s",
@@ -207,6 +256,49 @@ func (db *MySQLClient) BuildCreateTableStmt(cfg *config.Table) string {
 		),
 	)
 
+	if cfg.Options != nil {
+		sb.WriteString(db.BuildTableOptionsDesc(cfg.Options))
+	}
+
+	if cfg.Partitioning != nil {
+		sb.WriteString(db.BuildPartitioningDesc(cfg))
+	}
+
+	return sb.String()
+}
+
+// BuildTableOptionsDesc generate a table options part of sql for MySQL.
+func (db *MySQLClient) BuildTableOptionsDesc(cfg *config.TableOptions) string {
+	var sb strings.Builder
+
+	if cfg.Engine != "" {
+		sb.WriteString(fmt.Sprintf(" ENGINE=%s", cfg.Engine))
+	}
+
+	if cfg.Collate != "" {
+		sb.WriteString(fmt.Sprintf(" COLLATE=%s", cfg.Collate))
+	}
+
+	if cfg.RowFormat != "" {
+		sb.WriteString(fmt.Sprintf(" ROW_FORMAT=%s", cfg.RowFormat))
+	}
+
+	if cfg.KeyBlockSize != 0 {
+		sb.WriteString(fmt.Sprintf(" KEY_BLOCK_SIZE=%d", cfg.KeyBlockSize))
+	}
+
+	if cfg.Compression != "" {
+		sb.WriteString(fmt.Sprintf(" COMPRESSION=%s", quoteString(cfg.Compression)))
+	}
+
+	if cfg.AutoIncrement != 0 {
+		sb.WriteString(fmt.Sprintf(" AUTO_INCREMENT=%d", cfg.AutoIncrement))
+	}
+
+	if cfg.Comment != "" {
+		sb.WriteString(fmt.Sprintf(" COMMENT=%s", quoteString(cfg.Comment)))
+	}
+
 	return sb.String()
 }
 
@@ -216,24 +308,24 @@ func (db *MySQLClient) buildCreateTableStmtColumn(cfg *config.Column) string {
 	sb.WriteString(
 		fmt.Sprintf(
 			"    %s %s",
-			cfg.Name,
-			cfg.Type,
+			quoteIdentifier(cfg.Name),
+			db.buildColumnType(cfg),
 		),
 	)
 
-	if utils.Contains(OrderRequiredDataTypes, cfg.Type) {
-		sb.WriteString(fmt.Sprintf("(%d)", cfg.Order))
+	if cfg.IsSpatial() && cfg.SRID != 0 {
+		sb.WriteString(fmt.Sprintf(" SRID %d", cfg.SRID))
 	}
 
-	if utils.Contains(PrecisionRequiredDataTypes, cfg.Type) {
-		sb.WriteString(fmt.Sprintf("(%d, %d)", cfg.Order, cfg.Precision))
+	if cfg.Collate != "" {
+		sb.WriteString(fmt.Sprintf(" COLLATE %s", cfg.Collate))
 	}
 
-	if utils.Contains(UnsignedableDataType, cfg.Type) && cfg.Unsigned {
-		sb.WriteString(" UNSIGNED")
+	if cfg.IsGenerated() {
+		sb.WriteString(db.BuildGeneratedDesc(cfg))
 	}
 
-	if utils.Contains(IncrementableDataType, cfg.Type) && cfg.AutoIncrement {
+	if utils.Contains(IncrementableDataType, cfg.Type) && cfg.AutoIncrement && !cfg.IsGenerated() {
 		sb.WriteString(" AUTO_INCREMENT")
 	}
 
@@ -241,7 +333,7 @@ func (db *MySQLClient) buildCreateTableStmtColumn(cfg *config.Column) string {
 		sb.WriteString(" NOT NULL")
 	}
 
-	if !utils.Contains(ProhibitDefaultDataTypes, cfg.Type) && cfg.Default != nil {
+	if !utils.Contains(ProhibitDefaultDataTypes, cfg.Type) && cfg.Default != nil && !cfg.IsGenerated() {
 		sb.WriteString(db.BuildDefaultDesc(cfg))
 	}
 
@@ -249,6 +341,35 @@ func (db *MySQLClient) buildCreateTableStmtColumn(cfg *config.Column) string {
 		sb.WriteString(" PRIMARY KEY")
 	}
 
+	if cfg.Comment != "" {
+		sb.WriteString(fmt.Sprintf(" COMMENT %s", quoteString(cfg.Comment)))
+	}
+
+	return sb.String()
+}
+
+// buildColumnType generate a data type part of a column desc, like COLUMN_TYPE of information_schema.
+func (db *MySQLClient) buildColumnType(cfg *config.Column) string {
+	var sb strings.Builder
+
+	sb.WriteString(cfg.Type)
+
+	if utils.Contains(OrderRequiredDataTypes, cfg.Type) {
+		sb.WriteString(fmt.Sprintf("(%d)", cfg.Order))
+	}
+
+	if utils.Contains(MemberRequiredDataTypes, cfg.Type) {
+		sb.WriteString(db.BuildMembersDesc(cfg))
+	}
+
+	if utils.Contains(PrecisionRequiredDataTypes, cfg.Type) {
+		sb.WriteString(fmt.Sprintf("(%d, %d)", cfg.Order, cfg.Precision))
+	}
+
+	if utils.Contains(UnsignedableDataType, cfg.Type) && cfg.Unsigned {
+		sb.WriteString(" UNSIGNED")
+	}
+
 	return sb.String()
 }
 
@@ -262,6 +383,58 @@ func (db *MySQLClient) BuildDefaultDesc(cfg *config.Column) string {
 	}
 }
 
+// BuildGeneratedDesc generate a generated column desc part of sql for MySQL.
+func (db *MySQLClient) BuildGeneratedDesc(cfg *config.Column) string {
+	storage := "VIRTUAL"
+	if cfg.Stored {
+		storage = "STORED"
+	}
+
+	return fmt.Sprintf(" GENERATED ALWAYS AS (%s) %s", cfg.As, storage)
+}
+
+// BuildCheckDesc generate a check constraint desc part of sql for MySQL.
+func (db *MySQLClient) BuildCheckDesc(cfg *config.Check) string {
+	if cfg.Name == "" {
+		return fmt.Sprintf("    CHECK (%s)", cfg.Expr)
+	}
+
+	return fmt.Sprintf("    CONSTRAINT %s CHECK (%s)", quoteIdentifier(cfg.Name), cfg.Expr)
+}
+
+// BuildMembersDesc generate a member list part of enum/set sql for MySQL.
+func (db *MySQLClient) BuildMembersDesc(cfg *config.Column) string {
+	members := columnMembers(cfg)
+	for i, m := range members {
+		members[i] = quoteString(m)
+	}
+
+	return "(" + strings.Join(members, ", ") + ")"
+}
+
+// quoteIdentifier quotes an identifier like table and column names w/ backticks for MySQL.
+func quoteIdentifier(s string) string {
+	return "`" + strings.ReplaceAll(s, "`", "``") + "`"
+}
+
+// quoteIdentifiers quotes identifiers, then joins them.
+func quoteIdentifiers(ss []string) string {
+	quoted := make([]string, 0, len(ss))
+	for _, s := range ss {
+		quoted = append(quoted, quoteIdentifier(s))
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+// quoteString quotes a string literal for MySQL.
+func quoteString(s string) string {
+	s = strings.ReplaceAll(s, "\\", "\\\\")
+	s = strings.ReplaceAll(s, "'", "''")
+
+	return "'" + s + "'"
+}
+
 // BuildIndexDesc generate an index desc part of sql for MySQL.
 func (db *MySQLClient) BuildIndexDesc(cfg *config.Index) string {
 	var sb strings.Builder
@@ -270,30 +443,73 @@ func (db *MySQLClient) BuildIndexDesc(cfg *config.Index) string {
 		sb.WriteString("    PRIMARY KEY ")
 	} else if cfg.Uniq {
 		sb.WriteString("    UNIQUE ")
+	} else if cfg.Spatial {
+		sb.WriteString("    SPATIAL INDEX ")
+	} else if cfg.Fulltext {
+		sb.WriteString("    FULLTEXT INDEX ")
 	} else {
 		sb.WriteString("    INDEX ")
 	}
 
 	if cfg.Name != "" {
-		sb.WriteString(cfg.Name)
+		sb.WriteString(quoteIdentifier(cfg.Name))
+	}
+
+	if cfg.Using != "" {
+		sb.WriteString(fmt.Sprintf(" USING %s", strings.ToUpper(cfg.Using)))
+	}
+
+	parts := make([]string, 0, len(cfg.KeyParts()))
+	for _, part := range cfg.KeyParts() {
+		parts = append(parts, db.BuildKeyPartDesc(part))
 	}
 
 	sb.WriteString(" (")
-	sb.WriteString(strings.Join(cfg.Columns, ", "))
+	sb.WriteString(strings.Join(parts, ", "))
 	sb.WriteString(")")
 
+	if cfg.Parser != "" {
+		sb.WriteString(fmt.Sprintf(" WITH PARSER %s", cfg.Parser))
+	}
+
+	if cfg.Invisible {
+		sb.WriteString(" INVISIBLE")
+	}
+
+	return sb.String()
+}
+
+// BuildKeyPartDesc generate a key part desc of an index for MySQL.
+func (db *MySQLClient) BuildKeyPartDesc(cfg *config.KeyPart) string {
+	var sb strings.Builder
+
+	if cfg.Expr != "" {
+		// functional key part must be enclosed within parentheses
+		sb.WriteString(fmt.Sprintf("(%s)", cfg.Expr))
+	} else {
+		sb.WriteString(quoteIdentifier(cfg.Column))
+	}
+
+	if cfg.Length != 0 {
+		sb.WriteString(fmt.Sprintf("(%d)", cfg.Length))
+	}
+
+	if cfg.Order != "" {
+		sb.WriteString(" " + strings.ToUpper(cfg.Order))
+	}
+
 	return sb.String()
 }
 
 // DropTable does DropTable statement for MySQL.
-func (db *MySQLClient) DropTable(cfg *config.Table) error {
+func (db *MySQLClient) DropTable(ctx context.Context, cfg *config.Table) error {
 	sql := db.BuildDropTableStmt(cfg)
 
 	if Verbose {
 		fmt.Println(sql)
 	}
 
-	if _, err := db.Exec(sql); err != nil {
+	if _, err := db.ExecContext(ctx, sql); err != nil {
 		return err
 	}
 
@@ -304,118 +520,701 @@ func (db *MySQLClient) DropTable(cfg *config.Table) error {
 func (db *MySQLClient) BuildDropTableStmt(cfg *config.Table) string {
 	return fmt.Sprintf(
 		"DROP TABLE IF EXISTS %s",
-		cfg.Name,
+		quoteIdentifier(cfg.Name),
 	)
 }
 
-// Populate does Insert statement for MySQL.
-func (db *MySQLClient) Populate(cfg *config.Table) error {
+// Populate does Insert statement for MySQL, then returns the report of inserted records.
+// Records are generated in batches, which are inserted by a fixed number of workers.
+// When ctx is canceled, no more batches are started and the statements in flight are canceled.
+func (db *MySQLClient) Populate(ctx context.Context, cfg *config.Table) (*Report, error) {
+	if err := db.loadValuesQueries(ctx, cfg); err != nil {
+		return &Report{}, err
+	}
+
+	batchSize := cfg.BatchSize
+	if batchSize <= 0 {
+		batchSize = config.DefaultBatchSize
+	}
+
+	batchBytes := maxBatchBytes(cfg)
+
+	workers := max(cfg.Concurrency, 1)
+
+	var tuner *BatchTuner
+	if cfg.AutoBatch != nil {
+		tuner = NewBatchTuner(cfg.AutoBatch, batchSize, workers)
+		workers = max(cfg.AutoBatch.MaxConcurrency, 1)
+	}
+
+	throttler, err := NewThrottler(ctx, db.DB, cfg.Throttle)
+	if err != nil {
+		return &Report{}, err
+	}
+	defer throttler.Close()
+
+	result := &populateResult{}
+
+	// the buffer is bounded, so generation waits for workers when they fall behind
+	batches := make(chan [][]interface{}, workers)
+
 	var wg sync.WaitGroup
 
-	otherConnections := 100
-	batchSize := 200
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
 
-	if cfg.Record < batchSize {
-		batchSize = cfg.Record
+		go func(worker int) {
+			defer wg.Done()
+			db.work(ctx, cfg, worker, batches, result, tuner, throttler)
+		}(w)
 	}
 
-	i := 0
-	for i < cfg.Record {
-		// Not try to exec query
-		// it would return "Error 1040: Too many connections"
-		var currentConnections int
-		err := db.QueryRow("select count(*) from information_schema.PROCESSLIST").Scan(&currentConnections)
+	progress := startProgress(cfg, result)
 
-		if err != nil {
-			fmt.Println(err)
+	b := &batcher{size: batchSize, limit: batchBytes}
 
-			currentConnections = MaxConnections
+	for i := 0; i < cfg.Record && !result.aborted(cfg) && ctx.Err() == nil; i++ {
+		if tuner != nil {
+			b.size = tuner.BatchSize()
 		}
 
-		if currentConnections+otherConnections < MaxConnections {
-			wg.Add(1)
+		for _, batch := range b.add(db.generateInsertRow(cfg)) {
+			batches <- batch
+		}
+	}
 
-			rows := make([]string, batchSize)
-			for j := 0; j < batchSize; j++ {
-				rows[j] = db.generateInsertRow(cfg)
-			}
+	if rows := b.flush(); len(rows) > 0 && !result.aborted(cfg) && ctx.Err() == nil {
+		batches <- rows
+	}
+
+	close(batches)
+
+	if tuner != nil {
+		tuner.stop()
+	}
 
-			go func() {
-				if err := db.execInsertStmt(cfg, rows); err != nil {
-					fmt.Println(err)
-				}
+	wg.Wait()
 
-				wg.Done()
-			}()
+	progress.stop()
 
-			i += batchSize
+	if tuner != nil {
+		state := "ended"
+		if tuner.Settled() {
+			state = "settled"
 		}
+
+		fmt.Printf("table %s: auto batch %s w/ batchSize %d and concurrency %d\n", cfg.Name, state, tuner.BatchSize(), tuner.Concurrency())
 	}
 
-	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return result.report(), fmt.Errorf("populating table %s is interrupted: %w", cfg.Name, err)
+	}
+
+	return result.report(), result.err(cfg)
+}
+
+// work inserts batches until they are closed, only while the worker is active on auto batch and the server is healthy.
+func (db *MySQLClient) work(
+	ctx context.Context,
+	cfg *config.Table,
+	worker int,
+	batches <-chan [][]interface{},
+	result *populateResult,
+	tuner *BatchTuner,
+	throttler *Throttler,
+) {
+	for {
+		if tuner != nil {
+			tuner.acquire(worker)
+		}
+
+		rows, ok := <-batches
+		if !ok {
+			return
+		}
+
+		// drain the queued batches w/o inserting them
+		if result.aborted(cfg) || ctx.Err() != nil {
+			continue
+		}
+
+		if err := throttler.Wait(ctx); err != nil {
+			continue
+		}
+
+		start := time.Now()
+		err := db.insertBatch(ctx, cfg, rows, result)
+
+		if tuner != nil && err == nil {
+			tuner.Observe(len(rows), time.Since(start))
+		}
+	}
+}
+
+// batcher accumulates rows into batches limited by the number of rows and their estimated bytes.
+type batcher struct {
+	size  int
+	limit int
+	rows  [][]interface{}
+	bytes int
+}
+
+// add appends the row, then returns the batches which are ready to insert.
+func (b *batcher) add(row []interface{}) [][][]interface{} {
+	var ready [][][]interface{}
+
+	size := estimateRowBytes(row)
+
+	// a row larger than the limit is sent alone
+	if len(b.rows) > 0 && b.limit > 0 && b.bytes+size > b.limit {
+		ready = append(ready, b.flush())
+	}
+
+	b.rows = append(b.rows, row)
+	b.bytes += size
+
+	if len(b.rows) >= b.size {
+		ready = append(ready, b.flush())
+	}
+
+	return ready
+}
+
+// flush returns the accumulated rows, then starts a new batch.
+func (b *batcher) flush() [][]interface{} {
+	rows := b.rows
+	b.rows, b.bytes = nil, 0
+
+	return rows
+}
+
+// maxBatchBytes returns the limit of bytes of a batch, 0 means unlimited.
+// Some room of max_allowed_packet is left for the statement itself.
+func maxBatchBytes(cfg *config.Table) int {
+	limit := MaxAllowedPacket * 9 / 10
+
+	if cfg.BatchBytes > 0 && (limit == 0 || cfg.BatchBytes < limit) {
+		limit = cfg.BatchBytes
+	}
+
+	return limit
+}
+
+// estimateRowBytes estimates the size of the row sent to the server.
+func estimateRowBytes(row []interface{}) int {
+	bytes := 0
+
+	for _, v := range row {
+		switch v := v.(type) {
+		case string:
+			bytes += len(v)
+		case []byte:
+			bytes += len(v)
+		case sqlFunc:
+			bytes += len(v.name) + estimateRowBytes(v.args)
+		default:
+			bytes += 8
+		}
+
+		// placeholder, separator and length of the value
+		bytes += 4
+	}
+
+	return bytes
+}
+
+// insertBatch inserts rows by statements under the placeholder limit, then returns the first error.
+func (db *MySQLClient) insertBatch(ctx context.Context, cfg *config.Table, rows [][]interface{}, result *populateResult) error {
+	var first error
+
+	for _, chunk := range splitRows(rows, maxPlaceholders) {
+		err := db.execWithRetry(ctx, cfg, chunk, result)
+
+		// the outcome of the canceled statement is unknown, since the driver closes the connection
+		// and the insert may have been committed already, so its records are neither inserted nor failed
+		if err != nil && ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		result.add(chunk, err)
+
+		if err != nil && first == nil {
+			first = err
+		}
+	}
+
+	return first
+}
+
+// Report represents the result of populating a table.
+type Report struct {
+	Inserted int
+	Failed   int
+	Retries  int
+}
+
+// populateResult aggregates the results of batches running concurrently.
+type populateResult struct {
+	mu       sync.Mutex
+	inserted int
+	failed   int
+	retries  int
+	bytes    int64
+	firstErr error
+}
+
+func (r *populateResult) add(rows [][]interface{}, err error) {
+	var bytes int64
+	for _, row := range rows {
+		bytes += int64(estimateRowBytes(row))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err == nil {
+		r.inserted += len(rows)
+		r.bytes += bytes
+
+		return
+	}
+
+	r.failed += len(rows)
+	if r.firstErr == nil {
+		r.firstErr = err
+	}
+}
+
+func (r *populateResult) retried() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.retries++
+}
+
+// aborted returns whether populating should stop, which never happens under the skip policy.
+func (r *populateResult) aborted(cfg *config.Table) bool {
+	if cfg.OnError == config.OnErrorSkip {
+		return false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.firstErr != nil
+}
+
+func (r *populateResult) sentBytes() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.bytes
+}
+
+func (r *populateResult) report() *Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return &Report{Inserted: r.inserted, Failed: r.failed, Retries: r.retries}
+}
+
+func (r *populateResult) err(cfg *config.Table) error {
+	if r.firstErr == nil {
+		return nil
+	}
+
+	return fmt.Errorf("failed to insert %d records into table %s: %w", r.failed, cfg.Name, r.firstErr)
+}
+
+// loadValuesQueries runs valuesQuery of each column once, then holds the result set as its value pool.
+func (db *MySQLClient) loadValuesQueries(ctx context.Context, cfg *config.Table) error {
+	for _, column := range cfg.Columns {
+		if column.ValuesQuery == "" || column.Pool() != nil {
+			continue
+		}
+
+		if Verbose {
+			fmt.Println(column.ValuesQuery)
+		}
+
+		pool, err := db.queryValuePool(ctx, column.ValuesQuery)
+		if err != nil {
+			return fmt.Errorf("valuesQuery of column %s.%s is failed: %w", cfg.Name, column.Name, err)
+		}
+
+		column.SetPool(pool)
+	}
 
 	return nil
 }
 
-func (db *MySQLClient) execInsertStmt(cfg *config.Table, values []string) error {
-	sql := db.BuildInsertStmt(cfg, values)
+// queryValuePool reads the 1st column of the result set as values and the optional 2nd column as weights.
+func (db *MySQLClient) queryValuePool(ctx context.Context, query string) (*config.ValuePool, error) {
+	rows, err := db.QueryxContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var (
+		values   []interface{}
+		weights  []float64
+		weighted bool
+	)
+
+	for rows.Next() {
+		row, err := rows.SliceScan()
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, scannedValue(row[0]))
+
+		if len(row) < 2 {
+			continue
+		}
+
+		w, err := strconv.ParseFloat(fmt.Sprint(scannedValue(row[1])), 64)
+		if err != nil {
+			return nil, fmt.Errorf("weight is not a number: %w", err)
+		}
+
+		weights = append(weights, w)
+		weighted = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(values) == 0 {
+		return nil, errors.New("query returned no rows")
+	}
+
+	if !weighted {
+		weights = nil
+	}
+
+	return config.NewValuePool(values, weights)
+}
+
+// scannedValue converts raw bytes returned by the driver into string.
+func scannedValue(v interface{}) interface{} {
+	if b, ok := v.([]byte); ok {
+		return string(b)
+	}
+
+	return v
+}
+
+func (db *MySQLClient) execInsertStmt(ctx context.Context, cfg *config.Table, rows [][]interface{}) error {
+	if err := RowLimiter.Wait(ctx, len(rows)); err != nil {
+		return err
+	}
+
+	if err := QueryLimiter.Wait(ctx, 1); err != nil {
+		return err
+	}
 
 	if Verbose {
-		fmt.Println(sql)
+		fmt.Println(db.BuildInsertStmt(cfg, rows))
 	}
 
-	if _, err := db.Exec(sql); err != nil {
+	query, args := db.BuildInsertQuery(cfg, rows)
+	if _, err := db.ExecContext(ctx, query, args...); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-// BuildInsertStmt generate insert_stmt sql for MySQL.
-func (db *MySQLClient) BuildInsertStmt(cfg *config.Table, values []string) string {
+// splitRows splits rows into chunks, each of which has placeholders up to the limit.
+func splitRows(rows [][]interface{}, limit int) [][][]interface{} {
+	var (
+		chunks [][][]interface{}
+		start  int
+		count  int
+	)
+
+	for i, row := range rows {
+		n := 0
+		for _, v := range row {
+			n += placeholders(v)
+		}
+
+		if count+n > limit && i > start {
+			chunks = append(chunks, rows[start:i])
+			start, count = i, 0
+		}
+
+		count += n
+	}
+
+	if start < len(rows) {
+		chunks = append(chunks, rows[start:])
+	}
+
+	return chunks
+}
+
+func placeholders(v interface{}) int {
+	if f, ok := v.(sqlFunc); ok {
+		return len(f.args)
+	}
+
+	return 1
+}
+
+func (db *MySQLClient) buildInsertHeader(cfg *config.Table) string {
+	columns := cfg.InsertableColumns()
+
+	reg := make([]string, 0, len(columns))
+	for _, column := range columns {
+		reg = append(reg, quoteIdentifier(column.Name))
+	}
+
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES ", quoteIdentifier(cfg.Name), strings.Join(reg, ", "))
+}
+
+// BuildInsertQuery generate insert_stmt sql w/ placeholders and its args for MySQL.
+func (db *MySQLClient) BuildInsertQuery(cfg *config.Table, rows [][]interface{}) (string, []interface{}) {
 	var sb strings.Builder
 
-	reg := make([]string, 0, len(cfg.Columns))
-	for _, column := range cfg.Columns {
-		reg = append(reg, column.Name)
+	sb.WriteString(db.buildInsertHeader(cfg))
+
+	args := make([]interface{}, 0, len(rows)*len(cfg.Columns))
+	reg := make([]string, 0, len(rows))
+
+	for _, row := range rows {
+		values := make([]string, 0, len(row))
+
+		for _, v := range row {
+			if f, ok := v.(sqlFunc); ok {
+				values = append(values, f.name+"("+strings.TrimSuffix(strings.Repeat("?, ", len(f.args)), ", ")+")")
+				args = append(args, f.args...)
+
+				continue
+			}
+
+			values = append(values, "?")
+			args = append(args, v)
+		}
+
+		reg = append(reg, "("+strings.Join(values, ", ")+")")
 	}
 
-	sb.WriteString(
-		fmt.Sprintf(
-			"INSERT INTO %s (%s) VALUES (\n",
-			cfg.Name,
-			strings.Join(reg, ", "),
-		),
-	)
+	sb.WriteString(strings.Join(reg, ", "))
+
+	return sb.String(), args
+}
+
+// BuildInsertStmt generate insert_stmt sql w/ literal values for MySQL, which is used to show or dump the query.
+func (db *MySQLClient) BuildInsertStmt(cfg *config.Table, rows [][]interface{}) string {
+	var sb strings.Builder
+
+	sb.WriteString(db.buildInsertHeader(cfg))
+	sb.WriteString("(\n")
 
-	sb.WriteString(strings.Join(values, "\n), (\n"))
+	reg := make([]string, 0, len(rows))
+	for _, row := range rows {
+		values := make([]string, 0, len(row))
+		for _, v := range row {
+			values = append(values, "   "+BuildLiteral(v))
+		}
+
+		reg = append(reg, strings.Join(values, ",\n"))
+	}
+
+	sb.WriteString(strings.Join(reg, "\n), (\n"))
 	sb.WriteString("\n)")
 
 	return sb.String()
 }
 
-func (db *MySQLClient) generateInsertRow(cfg *config.Table) string {
+// BuildLiteral generate a literal of the value for MySQL.
+func BuildLiteral(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "NULL"
+	case sqlFunc:
+		args := make([]string, 0, len(v.args))
+		for _, arg := range v.args {
+			args = append(args, BuildLiteral(arg))
+		}
+
+		return v.name + "(" + strings.Join(args, ", ") + ")"
+	case []byte:
+		return fmt.Sprintf("X'%x'", v)
+	case string:
+		return quoteString(v)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func (db *MySQLClient) generateInsertRow(cfg *config.Table) []interface{} {
 	// generate insert values
-	reg := make([]string, 0, len(cfg.Columns))
+	columns := cfg.InsertableColumns()
+	row := make([]interface{}, 0, len(columns))
 
-	for _, column := range cfg.Columns {
-		value := db.generateValue(column)
-		switch value := value.(type) {
-		case string:
-			reg = append(reg, fmt.Sprintf("   '%v'", value))
-		case float32, float64:
-			var sb strings.Builder
-
-			sb.WriteString("   %.")
-			sb.WriteString(fmt.Sprintf("%d", column.Precision))
-			sb.WriteString("f")
-			reg = append(reg, fmt.Sprintf(sb.String(), value))
-		default:
-			reg = append(reg, fmt.Sprintf("   %v", value))
+	partitionColumn, partitionValue, partitioned := db.generatePartitionValue(cfg)
+
+	for _, column := range columns {
+		if partitioned && column.Name == partitionColumn {
+			row = append(row, partitionValue)
+			continue
 		}
+
+		row = append(row, db.generateValue(column))
 	}
 
-	return strings.Join(reg, ",\n")
+	return row
+}
+
+// wordsMaxChars returns the limit of characters of text made of words.
+func wordsMaxChars(cfg *config.Column) int {
+	switch cfg.Type {
+	case "char", "varchar":
+		return cfg.Order
+	case "tinytext":
+		return tinyTextSize
+	default:
+		return -1
+	}
+}
+
+// integerBounds returns the range of the integer data type.
+func integerBounds(cfg *config.Column) (minI, maxI float64, ok bool) {
+	bits := map[string]int{
+		"tinyint":   8,
+		"smallint":  16,
+		"mediumint": 24,
+		"int":       32,
+		"bigint":    64,
+	}
+
+	b, ok := bits[cfg.Type]
+	if !ok {
+		return 0, 0, false
+	}
+
+	if cfg.Unsigned {
+		return 0, math.Pow(2, float64(b)) - 1, true
+	}
+
+	return -math.Pow(2, float64(b-1)), math.Pow(2, float64(b-1)) - 1, true
+}
+
+// generateRangedValue returns random number between min and max of the column, e.g. derived from CHECK constraint.
+func (db *MySQLClient) generateRangedValue(cfg *config.Column) (interface{}, bool) {
+	var minF, maxF float64
+
+	integer := false
+	if lo, hi, ok := integerBounds(cfg); ok {
+		minF, maxF, integer = lo, hi, true
+	} else if utils.Contains(PrecisionRequiredDataTypes, cfg.Type) {
+		maxF = math.Pow(10, float64(cfg.Order-cfg.Precision)) - math.Pow(10, -float64(cfg.Precision))
+		if !cfg.Unsigned {
+			minF = -maxF
+		}
+	} else {
+		return nil, false
+	}
+
+	if cfg.Min != nil && *cfg.Min > minF {
+		minF = *cfg.Min
+	}
+
+	if cfg.Max != nil && *cfg.Max < maxF {
+		maxF = *cfg.Max
+	}
+
+	if integer {
+		// int64 cannot hold the max of unsigned bigint
+		maxF = math.Min(math.Floor(maxF), math.MaxInt64)
+
+		return rand.IntRange(int64(math.Ceil(minF)), int64(maxF)), true
+	}
+
+	// round down to the precision not to exceed the range on formatting
+	scale := math.Pow(10, float64(cfg.Precision))
+	v := math.Floor(gofakeit.Float64Range(minF, maxF)*scale) / scale
+
+	if v < minF {
+		v = math.Ceil(minF*scale) / scale
+	}
+
+	return v, true
+}
+
+// generateGeometry returns ST_GeomFromText call w/ random WKT inside the bounds of the column.
+func (db *MySQLClient) generateGeometry(cfg *config.Column) sqlFunc {
+	b := cfg.Bounds
+	if b == nil {
+		b = &config.Bounds{MinX: -180, MinY: -90, MaxX: 180, MaxY: 90}
+	}
+
+	var wkt string
+
+	switch cfg.Type {
+	case "point":
+		wkt = rand.Point(b.MinX, b.MinY, b.MaxX, b.MaxY)
+	case "linestring":
+		wkt = rand.LineString(b.MinX, b.MinY, b.MaxX, b.MaxY)
+	case "polygon":
+		wkt = rand.Polygon(b.MinX, b.MinY, b.MaxX, b.MaxY)
+	default:
+		wkt = rand.Geometry(b.MinX, b.MinY, b.MaxX, b.MaxY)
+	}
+
+	return geomFromText(wkt, cfg.SRID)
+}
+
+// geomFromText returns ST_GeomFromText call.
+// Geographic SRS like 4326 takes latitude first by default, so x is always treated as longitude.
+func geomFromText(wkt string, srid int) sqlFunc {
+	if srid == 0 {
+		return sqlFunc{name: "ST_GeomFromText", args: []interface{}{wkt}}
+	}
+
+	return sqlFunc{name: "ST_GeomFromText", args: []interface{}{wkt, srid, "axis-order=long-lat"}}
+}
+
+// BuildGeomFromText generate ST_GeomFromText call for MySQL.
+func BuildGeomFromText(wkt string, srid int) string {
+	return BuildLiteral(geomFromText(wkt, srid))
+}
+
+// bitValue converts a bit-value literal like b'0101' into the number.
+func bitValue(s string) uint64 {
+	v, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(s, "b'"), "'"), 2, 64)
+	if err != nil {
+		return 0
+	}
+
+	return v
+}
+
+func columnMembers(cfg *config.Column) []string {
+	members := make([]string, 0, len(cfg.Values))
+	for _, v := range cfg.Values {
+		members = append(members, fmt.Sprint(v))
+	}
+
+	return members
+}
+
+// enumMember returns the member of enum as string, nil is kept as NULL.
+func enumMember(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+
+	return fmt.Sprint(v)
 }
 
 //nolint:gocyclo,funlen
@@ -424,10 +1223,38 @@ func (db *MySQLClient) generateValue(cfg *config.Column) interface{} {
 		return 0
 	}
 
+	// set takes any subset of its members, so it is not picked from the value pool.
+	if cfg.Type == "set" {
+		return rand.Set(columnMembers(cfg))
+	}
+
+	// enum reads a number as the index of its members, so the member is bound as string.
+	if cfg.Type == "enum" {
+		if pool := cfg.Pool(); pool != nil {
+			return enumMember(pool.Pick())
+		}
+
+		return enumMember(utils.Shuffle(cfg.Values))
+	}
+
+	if pool := cfg.Pool(); pool != nil {
+		return pool.Pick()
+	}
+
 	if len(cfg.Values) > 0 {
 		return utils.Shuffle(cfg.Values)
 	}
 
+	if words := cfg.WordsGenerator(); words != nil {
+		return words.Text(cfg.Words.Min, cfg.Words.Max, wordsMaxChars(cfg))
+	}
+
+	if cfg.Min != nil || cfg.Max != nil {
+		if value, ok := db.generateRangedValue(cfg); ok {
+			return value
+		}
+	}
+
 	switch cfg.Type {
 	case "boolean":
 		return rand.Boolean()
@@ -496,7 +1323,7 @@ func (db *MySQLClient) generateValue(cfg *config.Column) interface{} {
 		return rand.Double(cfg.Order, cfg.Precision)
 
 	case "bit":
-		return rand.Bit(cfg.Order)
+		return bitValue(rand.Bit(cfg.Order))
 
 	case "date":
 		return rand.Date()
@@ -519,40 +1346,46 @@ func (db *MySQLClient) generateValue(cfg *config.Column) interface{} {
 		return rand.Year2()
 
 	case "char":
-		return rand.Char(cfg.Order)
+		return rand.Char(cfg.Order, cfg.Alphabet)
 
 	case "varchar":
-		return rand.VarChar(cfg.Order)
+		return rand.VarChar(cfg.Order, cfg.Alphabet)
 
 	case "binary":
-		return rand.Binary(cfg.Order)
+		return rand.Binary(cfg.Order, cfg.BinaryEntropy())
 
 	case "varbinary":
-		return rand.VarBinary(cfg.Order)
+		return rand.VarBinary(cfg.Order, cfg.BinaryEntropy())
 
 	case "tinyblob":
-		return rand.TinyBlob(tinyBlobSize)
+		return rand.TinyBlob(tinyBlobSize, cfg.BinaryEntropy())
 
 	case "tinytext":
-		return rand.TinyText(tinyTextSize)
+		return rand.TinyText(tinyTextSize, cfg.Alphabet)
 
 	case "blob":
-		return rand.Blob(blobSize)
+		return rand.Blob(blobSize, cfg.BinaryEntropy())
 
 	case "text":
-		return rand.Text(textSize)
+		return rand.Text(textSize, cfg.Alphabet)
 
 	case "mediumblob":
-		return rand.MediumBlob(mediumBlobSize)
+		return rand.MediumBlob(mediumBlobSize, cfg.BinaryEntropy())
 
 	case "mediumtext":
-		return rand.MediumText(mediumTextSize)
+		return rand.MediumText(mediumTextSize, cfg.Alphabet)
 
 	case "longblob":
-		return rand.LongBlob(longBlobSize)
+		return rand.LongBlob(longBlobSize, cfg.BinaryEntropy())
 
 	case "longtext":
-		return rand.LongText(longTextSize)
+		return rand.LongText(longTextSize, cfg.Alphabet)
+
+	case "json":
+		return GenerateJSON(cfg.Document)
+
+	case "geometry", "point", "linestring", "polygon":
+		return db.generateGeometry(cfg)
 
 	default:
 		return 0
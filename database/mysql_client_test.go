@@ -18,12 +18,22 @@ limitations under the License.
 package database_test
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/terakoya76/populator/config"
 	"github.com/terakoya76/populator/database"
+	"github.com/terakoya76/populator/rand"
 )
 
 var (
@@ -60,7 +70,7 @@ func Test_BuildCreateTableStmt_Columns(t *testing.T) {
 				Charset: "utf8mb4",
 				Record:  100000,
 			},
-			sql: "CREATE TABLE IF NOT EXISTS table_a (\n    col_1 boolean\n) DEFAULT CHARSET=utf8mb4",
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` boolean\n) DEFAULT CHARSET=utf8mb4",
 			err: nil,
 		},
 
@@ -88,7 +98,7 @@ func Test_BuildCreateTableStmt_Columns(t *testing.T) {
 				Charset: "utf8mb4",
 				Record:  100000,
 			},
-			sql: "CREATE TABLE IF NOT EXISTS table_a (\n    col_1 boolean NOT NULL DEFAULT(true) PRIMARY KEY\n) DEFAULT CHARSET=utf8mb4",
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` boolean NOT NULL DEFAULT(true) PRIMARY KEY\n) DEFAULT CHARSET=utf8mb4",
 			err: nil,
 		},
 
@@ -114,7 +124,7 @@ func Test_BuildCreateTableStmt_Columns(t *testing.T) {
 				Charset: "utf8mb4",
 				Record:  100000,
 			},
-			sql: "CREATE TABLE IF NOT EXISTS table_a (\n    col_1 tinyint(2)\n) DEFAULT CHARSET=utf8mb4",
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` tinyint(2)\n) DEFAULT CHARSET=utf8mb4",
 			err: nil,
 		},
 
@@ -140,7 +150,7 @@ func Test_BuildCreateTableStmt_Columns(t *testing.T) {
 				Charset: "utf8mb4",
 				Record:  100000,
 			},
-			sql: "CREATE TABLE IF NOT EXISTS table_a (\n    col_1 smallint(4)\n) DEFAULT CHARSET=utf8mb4",
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` smallint(4)\n) DEFAULT CHARSET=utf8mb4",
 			err: nil,
 		},
 
@@ -166,7 +176,7 @@ func Test_BuildCreateTableStmt_Columns(t *testing.T) {
 				Charset: "utf8mb4",
 				Record:  100000,
 			},
-			sql: "CREATE TABLE IF NOT EXISTS table_a (\n    col_1 mediumint(6)\n) DEFAULT CHARSET=utf8mb4",
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` mediumint(6)\n) DEFAULT CHARSET=utf8mb4",
 			err: nil,
 		},
 
@@ -192,7 +202,7 @@ func Test_BuildCreateTableStmt_Columns(t *testing.T) {
 				Charset: "utf8mb4",
 				Record:  100000,
 			},
-			sql: "CREATE TABLE IF NOT EXISTS table_a (\n    col_1 int(9)\n) DEFAULT CHARSET=utf8mb4",
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` int(9)\n) DEFAULT CHARSET=utf8mb4",
 			err: nil,
 		},
 
@@ -218,7 +228,7 @@ func Test_BuildCreateTableStmt_Columns(t *testing.T) {
 				Charset: "utf8mb4",
 				Record:  100000,
 			},
-			sql: "CREATE TABLE IF NOT EXISTS table_a (\n    col_1 bigint(11)\n) DEFAULT CHARSET=utf8mb4",
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` bigint(11)\n) DEFAULT CHARSET=utf8mb4",
 			err: nil,
 		},
 
@@ -247,7 +257,7 @@ func Test_BuildCreateTableStmt_Columns(t *testing.T) {
 				Record:  100000,
 			},
 			//nolint:lll
-			sql: "CREATE TABLE IF NOT EXISTS table_a (\n    col_1 bigint(20) UNSIGNED AUTO_INCREMENT NOT NULL DEFAULT(1000) PRIMARY KEY\n) DEFAULT CHARSET=utf8mb4",
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` bigint(20) UNSIGNED AUTO_INCREMENT NOT NULL DEFAULT(1000) PRIMARY KEY\n) DEFAULT CHARSET=utf8mb4",
 			err: nil,
 		},
 
@@ -273,7 +283,7 @@ func Test_BuildCreateTableStmt_Columns(t *testing.T) {
 				Charset: "utf8mb4",
 				Record:  100000,
 			},
-			sql: "CREATE TABLE IF NOT EXISTS table_a (\n    col_1 decimal(5, 2)\n) DEFAULT CHARSET=utf8mb4",
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` decimal(5, 2)\n) DEFAULT CHARSET=utf8mb4",
 			err: nil,
 		},
 
@@ -299,7 +309,7 @@ func Test_BuildCreateTableStmt_Columns(t *testing.T) {
 				Charset: "utf8mb4",
 				Record:  100000,
 			},
-			sql: "CREATE TABLE IF NOT EXISTS table_a (\n    col_1 float(10, 0)\n) DEFAULT CHARSET=utf8mb4",
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` float(10, 0)\n) DEFAULT CHARSET=utf8mb4",
 			err: nil,
 		},
 
@@ -325,7 +335,7 @@ func Test_BuildCreateTableStmt_Columns(t *testing.T) {
 				Charset: "utf8mb4",
 				Record:  100000,
 			},
-			sql: "CREATE TABLE IF NOT EXISTS table_a (\n    col_1 real(5, 2)\n) DEFAULT CHARSET=utf8mb4",
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` real(5, 2)\n) DEFAULT CHARSET=utf8mb4",
 			err: nil,
 		},
 
@@ -351,7 +361,7 @@ func Test_BuildCreateTableStmt_Columns(t *testing.T) {
 				Charset: "utf8mb4",
 				Record:  100000,
 			},
-			sql: "CREATE TABLE IF NOT EXISTS table_a (\n    col_1 double(5, 2)\n) DEFAULT CHARSET=utf8mb4",
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` double(5, 2)\n) DEFAULT CHARSET=utf8mb4",
 			err: nil,
 		},
 
@@ -380,7 +390,7 @@ func Test_BuildCreateTableStmt_Columns(t *testing.T) {
 				Record:  100000,
 			},
 			//nolint:lll
-			sql: "CREATE TABLE IF NOT EXISTS table_a (\n    col_1 float(5, 2) UNSIGNED NOT NULL DEFAULT(123.45) PRIMARY KEY\n) DEFAULT CHARSET=utf8mb4",
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` float(5, 2) UNSIGNED NOT NULL DEFAULT(123.45) PRIMARY KEY\n) DEFAULT CHARSET=utf8mb4",
 			err: nil,
 		},
 
@@ -406,7 +416,7 @@ func Test_BuildCreateTableStmt_Columns(t *testing.T) {
 				Charset: "utf8mb4",
 				Record:  100000,
 			},
-			sql: "CREATE TABLE IF NOT EXISTS table_a (\n    col_1 bit(8)\n) DEFAULT CHARSET=utf8mb4",
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` bit(8)\n) DEFAULT CHARSET=utf8mb4",
 			err: nil,
 		},
 
@@ -434,7 +444,7 @@ func Test_BuildCreateTableStmt_Columns(t *testing.T) {
 				Charset: "utf8mb4",
 				Record:  100000,
 			},
-			sql: "CREATE TABLE IF NOT EXISTS table_a (\n    col_1 bit(1) NOT NULL DEFAULT \"b'01010101'\" PRIMARY KEY\n) DEFAULT CHARSET=utf8mb4",
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` bit(1) NOT NULL DEFAULT \"b'01010101'\" PRIMARY KEY\n) DEFAULT CHARSET=utf8mb4",
 			err: nil,
 		},
 
@@ -460,7 +470,7 @@ func Test_BuildCreateTableStmt_Columns(t *testing.T) {
 				Charset: "utf8mb4",
 				Record:  100000,
 			},
-			sql: "CREATE TABLE IF NOT EXISTS table_a (\n    col_1 date\n) DEFAULT CHARSET=utf8mb4",
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` date\n) DEFAULT CHARSET=utf8mb4",
 			err: nil,
 		},
 
@@ -486,7 +496,7 @@ func Test_BuildCreateTableStmt_Columns(t *testing.T) {
 				Charset: "utf8mb4",
 				Record:  100000,
 			},
-			sql: "CREATE TABLE IF NOT EXISTS table_a (\n    col_1 datetime\n) DEFAULT CHARSET=utf8mb4",
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` datetime\n) DEFAULT CHARSET=utf8mb4",
 			err: nil,
 		},
 
@@ -512,7 +522,7 @@ func Test_BuildCreateTableStmt_Columns(t *testing.T) {
 				Charset: "utf8mb4",
 				Record:  100000,
 			},
-			sql: "CREATE TABLE IF NOT EXISTS table_a (\n    col_1 timestamp\n) DEFAULT CHARSET=utf8mb4",
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` timestamp\n) DEFAULT CHARSET=utf8mb4",
 			err: nil,
 		},
 
@@ -538,7 +548,7 @@ func Test_BuildCreateTableStmt_Columns(t *testing.T) {
 				Charset: "utf8mb4",
 				Record:  100000,
 			},
-			sql: "CREATE TABLE IF NOT EXISTS table_a (\n    col_1 time\n) DEFAULT CHARSET=utf8mb4",
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` time\n) DEFAULT CHARSET=utf8mb4",
 			err: nil,
 		},
 
@@ -564,7 +574,7 @@ func Test_BuildCreateTableStmt_Columns(t *testing.T) {
 				Charset: "utf8mb4",
 				Record:  100000,
 			},
-			sql: "CREATE TABLE IF NOT EXISTS table_a (\n    col_1 year(4)\n) DEFAULT CHARSET=utf8mb4",
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` year(4)\n) DEFAULT CHARSET=utf8mb4",
 			err: nil,
 		},
 
@@ -592,7 +602,7 @@ func Test_BuildCreateTableStmt_Columns(t *testing.T) {
 				Charset: "utf8mb4",
 				Record:  100000,
 			},
-			sql: "CREATE TABLE IF NOT EXISTS table_a (\n    col_1 date NOT NULL DEFAULT \"2000-12-01\" PRIMARY KEY\n) DEFAULT CHARSET=utf8mb4",
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` date NOT NULL DEFAULT \"2000-12-01\" PRIMARY KEY\n) DEFAULT CHARSET=utf8mb4",
 			err: nil,
 		},
 
@@ -618,7 +628,7 @@ func Test_BuildCreateTableStmt_Columns(t *testing.T) {
 				Charset: "utf8mb4",
 				Record:  100000,
 			},
-			sql: "CREATE TABLE IF NOT EXISTS table_a (\n    col_1 char(20)\n) DEFAULT CHARSET=utf8mb4",
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` char(20)\n) DEFAULT CHARSET=utf8mb4",
 			err: nil,
 		},
 
@@ -644,7 +654,7 @@ func Test_BuildCreateTableStmt_Columns(t *testing.T) {
 				Charset: "utf8mb4",
 				Record:  100000,
 			},
-			sql: "CREATE TABLE IF NOT EXISTS table_a (\n    col_1 varchar(20)\n) DEFAULT CHARSET=utf8mb4",
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` varchar(20)\n) DEFAULT CHARSET=utf8mb4",
 			err: nil,
 		},
 
@@ -670,7 +680,7 @@ func Test_BuildCreateTableStmt_Columns(t *testing.T) {
 				Charset: "utf8mb4",
 				Record:  100000,
 			},
-			sql: "CREATE TABLE IF NOT EXISTS table_a (\n    col_1 binary(20)\n) DEFAULT CHARSET=utf8mb4",
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` binary(20)\n) DEFAULT CHARSET=utf8mb4",
 			err: nil,
 		},
 
@@ -696,7 +706,7 @@ func Test_BuildCreateTableStmt_Columns(t *testing.T) {
 				Charset: "utf8mb4",
 				Record:  100000,
 			},
-			sql: "CREATE TABLE IF NOT EXISTS table_a (\n    col_1 varbinary(20)\n) DEFAULT CHARSET=utf8mb4",
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` varbinary(20)\n) DEFAULT CHARSET=utf8mb4",
 			err: nil,
 		},
 
@@ -722,7 +732,7 @@ func Test_BuildCreateTableStmt_Columns(t *testing.T) {
 				Charset: "utf8mb4",
 				Record:  100000,
 			},
-			sql: "CREATE TABLE IF NOT EXISTS table_a (\n    col_1 tinyblob\n) DEFAULT CHARSET=utf8mb4",
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` tinyblob\n) DEFAULT CHARSET=utf8mb4",
 			err: nil,
 		},
 
@@ -748,7 +758,7 @@ func Test_BuildCreateTableStmt_Columns(t *testing.T) {
 				Charset: "utf8mb4",
 				Record:  100000,
 			},
-			sql: "CREATE TABLE IF NOT EXISTS table_a (\n    col_1 tinytext\n) DEFAULT CHARSET=utf8mb4",
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` tinytext\n) DEFAULT CHARSET=utf8mb4",
 			err: nil,
 		},
 
@@ -774,7 +784,7 @@ func Test_BuildCreateTableStmt_Columns(t *testing.T) {
 				Charset: "utf8mb4",
 				Record:  100000,
 			},
-			sql: "CREATE TABLE IF NOT EXISTS table_a (\n    col_1 blob(100)\n) DEFAULT CHARSET=utf8mb4",
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` blob(100)\n) DEFAULT CHARSET=utf8mb4",
 			err: nil,
 		},
 
@@ -800,7 +810,7 @@ func Test_BuildCreateTableStmt_Columns(t *testing.T) {
 				Charset: "utf8mb4",
 				Record:  100000,
 			},
-			sql: "CREATE TABLE IF NOT EXISTS table_a (\n    col_1 text(100)\n) DEFAULT CHARSET=utf8mb4",
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` text(100)\n) DEFAULT CHARSET=utf8mb4",
 			err: nil,
 		},
 
@@ -826,7 +836,7 @@ func Test_BuildCreateTableStmt_Columns(t *testing.T) {
 				Charset: "utf8mb4",
 				Record:  100000,
 			},
-			sql: "CREATE TABLE IF NOT EXISTS table_a (\n    col_1 mediumblob\n) DEFAULT CHARSET=utf8mb4",
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` mediumblob\n) DEFAULT CHARSET=utf8mb4",
 			err: nil,
 		},
 
@@ -852,7 +862,7 @@ func Test_BuildCreateTableStmt_Columns(t *testing.T) {
 				Charset: "utf8mb4",
 				Record:  100000,
 			},
-			sql: "CREATE TABLE IF NOT EXISTS table_a (\n    col_1 mediumtext\n) DEFAULT CHARSET=utf8mb4",
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` mediumtext\n) DEFAULT CHARSET=utf8mb4",
 			err: nil,
 		},
 
@@ -878,7 +888,7 @@ func Test_BuildCreateTableStmt_Columns(t *testing.T) {
 				Charset: "utf8mb4",
 				Record:  100000,
 			},
-			sql: "CREATE TABLE IF NOT EXISTS table_a (\n    col_1 longblob\n) DEFAULT CHARSET=utf8mb4",
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` longblob\n) DEFAULT CHARSET=utf8mb4",
 			err: nil,
 		},
 
@@ -904,7 +914,7 @@ func Test_BuildCreateTableStmt_Columns(t *testing.T) {
 				Charset: "utf8mb4",
 				Record:  100000,
 			},
-			sql: "CREATE TABLE IF NOT EXISTS table_a (\n    col_1 longtext\n) DEFAULT CHARSET=utf8mb4",
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` longtext\n) DEFAULT CHARSET=utf8mb4",
 			err: nil,
 		},
 
@@ -932,7 +942,186 @@ func Test_BuildCreateTableStmt_Columns(t *testing.T) {
 				Charset: "utf8mb4",
 				Record:  100000,
 			},
-			sql: "CREATE TABLE IF NOT EXISTS table_a (\n    col_1 text(65535) NOT NULL PRIMARY KEY\n) DEFAULT CHARSET=utf8mb4",
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` text(65535) NOT NULL PRIMARY KEY\n) DEFAULT CHARSET=utf8mb4",
+			err: nil,
+		},
+
+		{
+			name: "json w/ default",
+			cfg: &config.Table{
+				Name: "table_a",
+				Columns: []*config.Column{
+					{
+						Name:    "col_1",
+						Type:    "json",
+						NotNull: true,
+						Default: "{}",
+						Values:  nilValues,
+					},
+				},
+				Indexes: []*config.Index{},
+				Charset: "utf8mb4",
+				Record:  100000,
+			},
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` json NOT NULL\n) DEFAULT CHARSET=utf8mb4",
+			err: nil,
+		},
+
+		{
+			name: "enum",
+			cfg: &config.Table{
+				Name: "table_a",
+				Columns: []*config.Column{
+					{
+						Name:    "col_1",
+						Type:    "enum",
+						NotNull: true,
+						Default: "small",
+						Values: []interface{}{
+							"small",
+							"medium",
+							"it's large",
+						},
+					},
+				},
+				Indexes: []*config.Index{},
+				Charset: "utf8mb4",
+				Record:  100000,
+			},
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` enum('small', 'medium', 'it''s large') NOT NULL DEFAULT \"small\"\n) DEFAULT CHARSET=utf8mb4",
+			err: nil,
+		},
+
+		{
+			name: "set",
+			cfg: &config.Table{
+				Name: "table_a",
+				Columns: []*config.Column{
+					{
+						Name: "col_1",
+						Type: "set",
+						Values: []interface{}{
+							"read",
+							"write",
+						},
+					},
+				},
+				Indexes: []*config.Index{},
+				Charset: "utf8mb4",
+				Record:  100000,
+			},
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` set('read', 'write')\n) DEFAULT CHARSET=utf8mb4",
+			err: nil,
+		},
+
+		{
+			name: "point w/ srid",
+			cfg: &config.Table{
+				Name: "table_a",
+				Columns: []*config.Column{
+					{
+						Name:    "col_1",
+						Type:    "point",
+						NotNull: true,
+						SRID:    4326,
+						Values:  nilValues,
+					},
+				},
+				Indexes: []*config.Index{},
+				Charset: "utf8mb4",
+				Record:  100000,
+			},
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` point SRID 4326 NOT NULL\n) DEFAULT CHARSET=utf8mb4",
+			err: nil,
+		},
+
+		{
+			name: "generated column",
+			cfg: &config.Table{
+				Name: "table_a",
+				Columns: []*config.Column{
+					{
+						Name:   "col_1",
+						Type:   "json",
+						Values: nilValues,
+					},
+					{
+						Name:    "col_2",
+						Type:    "int",
+						Order:   11,
+						NotNull: true,
+						As:      "col_1->>'$.id'",
+						Stored:  true,
+						Values:  nilValues,
+					},
+				},
+				Indexes: []*config.Index{},
+				Charset: "utf8mb4",
+				Record:  100000,
+			},
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` json,\n    `col_2` int(11) GENERATED ALWAYS AS (col_1->>'$.id') STORED NOT NULL\n) DEFAULT CHARSET=utf8mb4",
+			err: nil,
+		},
+
+		{
+			name: "collate, comment and check",
+			cfg: &config.Table{
+				Name: "table_a",
+				Columns: []*config.Column{
+					{
+						Name:    "col_1",
+						Type:    "varchar",
+						Order:   20,
+						Collate: "utf8mb4_bin",
+						Comment: "user's name",
+						Values:  nilValues,
+					},
+					{
+						Name:   "col_2",
+						Type:   "int",
+						Order:  11,
+						Values: nilValues,
+					},
+				},
+				Indexes: []*config.Index{},
+				Checks: []*config.Check{
+					{Name: "chk_1", Expr: "col_2 BETWEEN 0 AND 100"},
+					{Expr: "col_1 <> ''"},
+				},
+				Charset: "utf8mb4",
+				Record:  100000,
+			},
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` varchar(20) COLLATE utf8mb4_bin COMMENT 'user''s name',\n    `col_2` int(11),\n" +
+				"    CONSTRAINT `chk_1` CHECK (col_2 BETWEEN 0 AND 100),\n    CHECK (col_1 <> '')\n) DEFAULT CHARSET=utf8mb4",
+			err: nil,
+		},
+
+		{
+			name: "table options",
+			cfg: &config.Table{
+				Name: "table_a",
+				Columns: []*config.Column{
+					{
+						Name:   "col_1",
+						Type:   "int",
+						Order:  11,
+						Values: nilValues,
+					},
+				},
+				Indexes: []*config.Index{},
+				Options: &config.TableOptions{
+					Engine:        "InnoDB",
+					Collate:       "utf8mb4_bin",
+					RowFormat:     "COMPRESSED",
+					KeyBlockSize:  8,
+					AutoIncrement: 1000,
+					Comment:       "seed data",
+				},
+				Charset: "utf8mb4",
+				Record:  100000,
+			},
+			sql: "CREATE TABLE IF NOT EXISTS `table_a` (\n    `col_1` int(11)\n) DEFAULT CHARSET=utf8mb4" +
+				" ENGINE=InnoDB COLLATE=utf8mb4_bin ROW_FORMAT=COMPRESSED KEY_BLOCK_SIZE=8 AUTO_INCREMENT=1000 COMMENT='seed data'",
 			err: nil,
 		},
 	}
@@ -1016,7 +1205,7 @@ func Test_BuildIndexDesc(t *testing.T) {
 					"col_1",
 				},
 			},
-			result: "    INDEX idx_1 (col_1)",
+			result: "    INDEX `idx_1` (`col_1`)",
 			err:    nil,
 		},
 
@@ -1031,7 +1220,7 @@ func Test_BuildIndexDesc(t *testing.T) {
 					"col_2",
 				},
 			},
-			result: "    INDEX idx_1 (col_1, col_2)",
+			result: "    INDEX `idx_1` (`col_1`, `col_2`)",
 			err:    nil,
 		},
 
@@ -1045,7 +1234,7 @@ func Test_BuildIndexDesc(t *testing.T) {
 					"col_1",
 				},
 			},
-			result: "    PRIMARY KEY  (col_1)",
+			result: "    PRIMARY KEY  (`col_1`)",
 			err:    nil,
 		},
 
@@ -1060,7 +1249,7 @@ func Test_BuildIndexDesc(t *testing.T) {
 					"col_2",
 				},
 			},
-			result: "    PRIMARY KEY  (col_1, col_2)",
+			result: "    PRIMARY KEY  (`col_1`, `col_2`)",
 			err:    nil,
 		},
 
@@ -1074,7 +1263,7 @@ func Test_BuildIndexDesc(t *testing.T) {
 					"col_1",
 				},
 			},
-			result: "    UNIQUE idx_1 (col_1)",
+			result: "    UNIQUE `idx_1` (`col_1`)",
 			err:    nil,
 		},
 
@@ -1089,7 +1278,75 @@ func Test_BuildIndexDesc(t *testing.T) {
 					"col_2",
 				},
 			},
-			result: "    UNIQUE idx_1 (col_1, col_2)",
+			result: "    UNIQUE `idx_1` (`col_1`, `col_2`)",
+			err:    nil,
+		},
+
+		{
+			name: "spatial key",
+			cfg: &config.Index{
+				Name:    "idx_1",
+				Spatial: true,
+				Columns: []string{
+					"col_1",
+				},
+			},
+			result: "    SPATIAL INDEX `idx_1` (`col_1`)",
+			err:    nil,
+		},
+
+		{
+			name: "fulltext key w/ parser",
+			cfg: &config.Index{
+				Name:     "idx_1",
+				Fulltext: true,
+				Parser:   "ngram",
+				Columns: []string{
+					"col_1",
+					"col_2",
+				},
+			},
+			result: "    FULLTEXT INDEX `idx_1` (`col_1`, `col_2`) WITH PARSER ngram",
+			err:    nil,
+		},
+
+		{
+			name: "prefix and descending key parts",
+			cfg: &config.Index{
+				Name: "idx_1",
+				Keys: []*config.KeyPart{
+					{Column: "col_1", Length: 20},
+					{Column: "col_2", Order: "desc"},
+				},
+			},
+			result: "    INDEX `idx_1` (`col_1`(20), `col_2` DESC)",
+			err:    nil,
+		},
+
+		{
+			name: "functional key part",
+			cfg: &config.Index{
+				Name: "idx_1",
+				Uniq: true,
+				Keys: []*config.KeyPart{
+					{Expr: "lower(col_1)"},
+				},
+			},
+			result: "    UNIQUE `idx_1` ((lower(col_1)))",
+			err:    nil,
+		},
+
+		{
+			name: "invisible index using btree",
+			cfg: &config.Index{
+				Name:      "idx_1",
+				Using:     "btree",
+				Invisible: true,
+				Columns: []string{
+					"col_1",
+				},
+			},
+			result: "    INDEX `idx_1` USING BTREE (`col_1`) INVISIBLE",
 			err:    nil,
 		},
 	}
@@ -1132,7 +1389,16 @@ func Test_BuildDropTableStmt(t *testing.T) {
 				Charset: "utf8mb4",
 				Record:  100000,
 			},
-			sql: "DROP TABLE IF EXISTS table_a",
+			sql: "DROP TABLE IF EXISTS `table_a`",
+			err: nil,
+		},
+
+		{
+			name: "reserved word and backtick in name",
+			cfg: &config.Table{
+				Name: "order`s",
+			},
+			sql: "DROP TABLE IF EXISTS `order``s`",
 			err: nil,
 		},
 	}
@@ -1146,3 +1412,625 @@ func Test_BuildDropTableStmt(t *testing.T) {
 		}
 	}
 }
+
+func Test_BuildGeomFromText(t *testing.T) {
+	cases := []struct {
+		name   string
+		wkt    string
+		srid   int
+		result string
+	}{
+		{
+			name:   "cartesian",
+			wkt:    "POINT(1.000000 2.000000)",
+			srid:   0,
+			result: "ST_GeomFromText('POINT(1.000000 2.000000)')",
+		},
+
+		{
+			name:   "geographic",
+			wkt:    "POINT(139.700000 35.600000)",
+			srid:   4326,
+			result: "ST_GeomFromText('POINT(139.700000 35.600000)', 4326, 'axis-order=long-lat')",
+		},
+	}
+
+	for _, c := range cases {
+		result := database.BuildGeomFromText(c.wkt, c.srid)
+
+		if !assert.Equal(t, c.result, result) {
+			t.Errorf("case: %s is failed, expected: %+v, actual: %+v\n", c.name, c.result, result)
+		}
+	}
+}
+
+func Test_BuildLiteral(t *testing.T) {
+	cases := []struct {
+		name   string
+		value  interface{}
+		result string
+	}{
+		{
+			name:   "nul and quotes in bytes",
+			value:  []byte{0x00, '\'', '"', '\\', 0xff},
+			result: "X'0027225cff'",
+		},
+		{
+			name:   "empty bytes",
+			value:  []byte{},
+			result: "X''",
+		},
+		{
+			name:   "quote in string",
+			value:  "it's",
+			result: "'it''s'",
+		},
+	}
+
+	for _, c := range cases {
+		result := database.BuildLiteral(c.value)
+
+		if !assert.Equal(t, c.result, result) {
+			t.Errorf("case: %s is failed, expected: %+v, actual: %+v\n", c.name, c.result, result)
+		}
+	}
+}
+
+func Test_BuildLiteral_Bytes(t *testing.T) {
+	b := rand.Bytes(1000, 1)
+	literal := database.BuildLiteral(b)
+
+	assert.True(t, strings.HasPrefix(literal, "X'") && strings.HasSuffix(literal, "'"))
+
+	decoded, err := hex.DecodeString(strings.TrimSuffix(strings.TrimPrefix(literal, "X'"), "'"))
+	assert.NoError(t, err)
+	assert.Equal(t, b, decoded)
+}
+
+func Test_BuildInsertStmt(t *testing.T) {
+	cases := []struct {
+		name string
+		cfg  *config.Table
+		rows [][]interface{}
+		sql  string
+	}{
+		{
+			name: "normal",
+			cfg: &config.Table{
+				Name: "table_a",
+				Columns: []*config.Column{
+					{
+						Name: "col_1",
+						Type: "int",
+					},
+					{
+						Name: "col_2",
+						Type: "varchar",
+					},
+				},
+			},
+			rows: [][]interface{}{
+				{1, "a"},
+				{2, "b"},
+			},
+			sql: "INSERT INTO `table_a` (`col_1`, `col_2`) VALUES (\n   1,\n   'a'\n), (\n   2,\n   'b'\n)",
+		},
+
+		{
+			name: "w/ generated column",
+			cfg: &config.Table{
+				Name: "table_a",
+				Columns: []*config.Column{
+					{
+						Name: "col_1",
+						Type: "int",
+					},
+					{
+						Name: "col_2",
+						Type: "int",
+						As:   "col_1 * 2",
+					},
+				},
+			},
+			rows: [][]interface{}{
+				{1},
+			},
+			sql: "INSERT INTO `table_a` (`col_1`) VALUES (\n   1\n)",
+		},
+
+		{
+			name: "w/ values to be escaped",
+			cfg: &config.Table{
+				Name: "table_a",
+				Columns: []*config.Column{
+					{
+						Name: "col_1",
+						Type: "varchar",
+					},
+					{
+						Name: "col_2",
+						Type: "blob",
+					},
+					{
+						Name: "col_3",
+						Type: "double",
+					},
+					{
+						Name: "col_4",
+						Type: "varchar",
+					},
+				},
+			},
+			rows: [][]interface{}{
+				{`it's a \ test`, []byte{0x00, 0x27}, 1.25, nil},
+			},
+			sql: "INSERT INTO `table_a` (`col_1`, `col_2`, `col_3`, `col_4`) VALUES (\n   'it''s a \\\\ test',\n   X'0027',\n   1.25,\n   NULL\n)",
+		},
+	}
+
+	for _, c := range cases {
+		client := database.MySQLClient{}
+		sql := client.BuildInsertStmt(c.cfg, c.rows)
+
+		if !assert.Equal(t, c.sql, sql) {
+			t.Errorf("case: %s is failed, expected: %+v, actual: %+v\n", c.name, c.sql, sql)
+		}
+	}
+}
+
+func Test_BuildInsertQuery(t *testing.T) {
+	cfg := &config.Table{
+		Name: "table_a",
+		Columns: []*config.Column{
+			{
+				Name: "col_1",
+				Type: "int",
+			},
+			{
+				Name: "col_2",
+				Type: "varchar",
+			},
+		},
+	}
+
+	client := database.MySQLClient{}
+	query, args := client.BuildInsertQuery(cfg, [][]interface{}{{1, "it's"}, {2, `\`}})
+
+	assert.Equal(t, "INSERT INTO `table_a` (`col_1`, `col_2`) VALUES (?, ?), (?, ?)", query)
+	assert.Equal(t, []interface{}{1, "it's", 2, `\`}, args)
+}
+
+func Test_BuildPartitioningDesc(t *testing.T) {
+	cases := []struct {
+		name    string
+		cfg     *config.Partitioning
+		columns []*config.Column
+		result  string
+	}{
+		{
+			name: "range over date column",
+			cfg: &config.Partitioning{
+				Type: "range",
+				Expr: "created_at",
+				Partitions: []*config.Partition{
+					{Name: "p202401", LessThan: "2024-02-01"},
+					{Name: "pmax", LessThan: "MAXVALUE"},
+				},
+			},
+			result: "\nPARTITION BY RANGE COLUMNS(`created_at`) (\n" +
+				"    PARTITION `p202401` VALUES LESS THAN ('2024-02-01'),\n" +
+				"    PARTITION `pmax` VALUES LESS THAN (MAXVALUE)\n)",
+		},
+
+		{
+			name: "range over timestamp column",
+			cfg: &config.Partitioning{
+				Type: "range",
+				Expr: "created_at",
+				Partitions: []*config.Partition{
+					{Name: "p202401", LessThan: "2024-02-01"},
+					{Name: "pmax", LessThan: "MAXVALUE"},
+				},
+			},
+			columns: []*config.Column{{Name: "created_at", Type: "timestamp"}},
+			result: "\nPARTITION BY RANGE (UNIX_TIMESTAMP(`created_at`)) (\n" +
+				"    PARTITION `p202401` VALUES LESS THAN (UNIX_TIMESTAMP('2024-02-01')),\n" +
+				"    PARTITION `pmax` VALUES LESS THAN (MAXVALUE)\n)",
+		},
+
+		{
+			name: "range over expression",
+			cfg: &config.Partitioning{
+				Type: "range",
+				Expr: "YEAR(created_at)",
+				Partitions: []*config.Partition{
+					{Name: "p2023", LessThan: 2024},
+				},
+			},
+			result: "\nPARTITION BY RANGE (YEAR(created_at)) (\n    PARTITION `p2023` VALUES LESS THAN (2024)\n)",
+		},
+
+		{
+			name: "list",
+			cfg: &config.Partitioning{
+				Type: "list",
+				Expr: "region",
+				Partitions: []*config.Partition{
+					{Name: "p_asia", In: []interface{}{"jp", "kr"}},
+					{Name: "p_eu", In: []interface{}{"de"}},
+				},
+			},
+			result: "\nPARTITION BY LIST COLUMNS(`region`) (\n" +
+				"    PARTITION `p_asia` VALUES IN ('jp', 'kr'),\n" +
+				"    PARTITION `p_eu` VALUES IN ('de')\n)",
+		},
+
+		{
+			name: "hash",
+			cfg: &config.Partitioning{
+				Type:  "hash",
+				Expr:  "id",
+				Count: 4,
+			},
+			result: "\nPARTITION BY HASH (id) PARTITIONS 4",
+		},
+
+		{
+			name: "key",
+			cfg: &config.Partitioning{
+				Type:  "key",
+				Count: 8,
+			},
+			result: "\nPARTITION BY KEY () PARTITIONS 8",
+		},
+	}
+
+	for _, c := range cases {
+		client := database.MySQLClient{}
+		result := client.BuildPartitioningDesc(&config.Table{Columns: c.columns, Partitioning: c.cfg})
+
+		if !assert.Equal(t, c.result, result) {
+			t.Errorf("case: %s is failed, expected: %+v, actual: %+v\n", c.name, c.result, result)
+		}
+	}
+}
+
+func Test_SplitRows(t *testing.T) {
+	row := func(values ...interface{}) []interface{} { return values }
+	point := database.SQLFunc("ST_GeomFromText", "POINT(1 1)", 4326)
+
+	cases := []struct {
+		name   string
+		rows   [][]interface{}
+		limit  int
+		result []int
+	}{
+		{
+			name:   "rows within the limit",
+			rows:   [][]interface{}{row(1, 2), row(3, 4)},
+			limit:  4,
+			result: []int{2},
+		},
+		{
+			name:   "row over the boundary starts the next chunk",
+			rows:   [][]interface{}{row(1, 2), row(3, 4), row(5, 6)},
+			limit:  5,
+			result: []int{2, 1},
+		},
+		{
+			name:   "function args count as placeholders",
+			rows:   [][]interface{}{row(1, point), row(2, point), row(3, point)},
+			limit:  6,
+			result: []int{2, 1},
+		},
+		{
+			name:   "single row over the limit is sent alone",
+			rows:   [][]interface{}{row(1, 2, 3), row(4), row(5, 6, 7)},
+			limit:  2,
+			result: []int{1, 1, 1},
+		},
+		{
+			name:   "no rows",
+			rows:   nil,
+			limit:  2,
+			result: nil,
+		},
+	}
+
+	for _, c := range cases {
+		var sizes []int
+		for _, chunk := range database.SplitRows(c.rows, c.limit) {
+			sizes = append(sizes, len(chunk))
+		}
+
+		if !assert.Equal(t, c.result, sizes) {
+			t.Errorf("case: %s is failed\n", c.name)
+		}
+	}
+}
+
+func Test_SplitRows_MaxPlaceholders(t *testing.T) {
+	// 65535 placeholders hold 13107 rows of 5 columns
+	rows := make([][]interface{}, 13108)
+	for i := range rows {
+		rows[i] = []interface{}{i, i, i, i, i}
+	}
+
+	chunks := database.SplitRows(rows, 65535)
+	if assert.Len(t, chunks, 2) {
+		assert.Len(t, chunks[0], 13107)
+		assert.Len(t, chunks[1], 1)
+	}
+}
+
+func Test_MaxBatchBytes(t *testing.T) {
+	cases := []struct {
+		name             string
+		maxAllowedPacket int
+		batchBytes       int
+		result           int
+	}{
+		{name: "unknown packet and unlimited", maxAllowedPacket: 0, batchBytes: 0, result: 0},
+		{name: "unknown packet", maxAllowedPacket: 0, batchBytes: 1000, result: 1000},
+		{name: "room of packet", maxAllowedPacket: 1000, batchBytes: 0, result: 900},
+		{name: "batchBytes under packet", maxAllowedPacket: 1000, batchBytes: 500, result: 500},
+		{name: "batchBytes over packet", maxAllowedPacket: 1000, batchBytes: 2000, result: 900},
+	}
+
+	defer func(v int) { database.MaxAllowedPacket = v }(database.MaxAllowedPacket)
+
+	for _, c := range cases {
+		database.MaxAllowedPacket = c.maxAllowedPacket
+
+		if !assert.Equal(t, c.result, database.MaxBatchBytes(&config.Table{BatchBytes: c.batchBytes})) {
+			t.Errorf("case: %s is failed\n", c.name)
+		}
+	}
+}
+
+func Test_EstimateRowBytes(t *testing.T) {
+	cases := []struct {
+		name   string
+		row    []interface{}
+		result int
+	}{
+		{name: "string", row: []interface{}{"abc"}, result: 7},
+		{name: "bytes", row: []interface{}{[]byte{1, 2}}, result: 6},
+		{name: "number and null", row: []interface{}{10, nil}, result: 24},
+		{name: "function", row: []interface{}{database.SQLFunc("ST_GeomFromText", "POINT(1 1)", 4326)}, result: 45},
+	}
+
+	for _, c := range cases {
+		if !assert.Equal(t, c.result, database.EstimateRowBytes(c.row)) {
+			t.Errorf("case: %s is failed\n", c.name)
+		}
+	}
+}
+
+func Test_Batch(t *testing.T) {
+	// a row of n bytes is estimated as n+4 bytes
+	rowOf := func(n int) []interface{} { return []interface{}{strings.Repeat("x", n-4)} }
+	rowsOf := func(sizes ...int) [][]interface{} {
+		rows := make([][]interface{}, 0, len(sizes))
+		for _, n := range sizes {
+			rows = append(rows, rowOf(n))
+		}
+
+		return rows
+	}
+
+	cases := []struct {
+		name   string
+		rows   [][]interface{}
+		size   int
+		limit  int
+		result []int
+	}{
+		{
+			name:   "cut by batchSize",
+			rows:   rowsOf(100, 100, 100, 100, 100, 100, 100),
+			size:   3,
+			limit:  0,
+			result: []int{3, 3, 1},
+		},
+		{
+			name:   "cut by bytes",
+			rows:   rowsOf(100, 100, 100, 100, 100),
+			size:   10,
+			limit:  250,
+			result: []int{2, 2, 1},
+		},
+		{
+			name:   "row larger than the limit is sent alone",
+			rows:   rowsOf(100, 1000, 100),
+			size:   10,
+			limit:  250,
+			result: []int{1, 1, 1},
+		},
+		{
+			name:   "row filling the limit exactly",
+			rows:   rowsOf(100, 150, 100),
+			size:   10,
+			limit:  250,
+			result: []int{2, 1},
+		},
+	}
+
+	for _, c := range cases {
+		var sizes []int
+		for _, batch := range database.Batch(c.rows, c.size, c.limit) {
+			sizes = append(sizes, len(batch))
+		}
+
+		if !assert.Equal(t, c.result, sizes) {
+			t.Errorf("case: %s is failed\n", c.name)
+		}
+	}
+}
+
+func Test_GenerateValue_Enum(t *testing.T) {
+	pool, err := config.NewValuePool([]interface{}{2, 1}, nil)
+	assert.NoError(t, err)
+
+	pooled := &config.Column{Name: "col_1", Type: "enum", Values: []interface{}{2, 1}}
+	pooled.SetPool(pool)
+
+	cases := []struct {
+		name   string
+		column *config.Column
+	}{
+		{
+			name:   "numeric members",
+			column: &config.Column{Name: "col_1", Type: "enum", Values: []interface{}{2, 1}},
+		},
+		{
+			name:   "numeric members in pool",
+			column: pooled,
+		},
+	}
+
+	client := database.MySQLClient{}
+
+	for _, c := range cases {
+		for range 10 {
+			if !assert.Contains(t, []interface{}{"2", "1"}, client.GenerateValue(c.column)) {
+				t.Errorf("case: %s is failed\n", c.name)
+			}
+		}
+	}
+}
+
+// fakeConnector connects to a fake server which runs exec on every statement w/ the number of args.
+type fakeConnector struct {
+	exec func(ctx context.Context, args int) error
+}
+
+func (c *fakeConnector) Connect(context.Context) (driver.Conn, error) {
+	return &fakeConn{exec: c.exec}, nil
+}
+
+func (c *fakeConnector) Driver() driver.Driver {
+	return nil
+}
+
+type fakeConn struct {
+	exec func(ctx context.Context, args int) error
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *fakeConn) ExecContext(ctx context.Context, _ string, args []driver.NamedValue) (driver.Result, error) {
+	if err := c.exec(ctx, len(args)); err != nil {
+		return nil, err
+	}
+
+	return driver.RowsAffected(len(args)), nil
+}
+
+func Test_Populate_Canceled(t *testing.T) {
+	cases := []struct {
+		name      string
+		record    int
+		batchSize int
+		limiter   *database.Limiter
+		timeout   time.Duration
+		// exec runs on the n-th statement, which may cancel populating
+		exec     func(ctx context.Context, cancel context.CancelFunc, n int64) error
+		execs    int64
+		inserted int
+	}{
+		{
+			name:      "no more batches after cancel",
+			record:    100,
+			batchSize: 10,
+			exec: func(_ context.Context, cancel context.CancelFunc, _ int64) error {
+				cancel()
+				return nil
+			},
+			execs:    1,
+			inserted: 10,
+		},
+		{
+			name:      "chunks completed before cancel are counted",
+			record:    65535 + 10,
+			batchSize: 65535 + 10,
+			exec: func(ctx context.Context, cancel context.CancelFunc, n int64) error {
+				if n == 1 {
+					return nil
+				}
+
+				// the statement in flight is canceled
+				cancel()
+				<-ctx.Done()
+
+				return ctx.Err()
+			},
+			execs:    2,
+			inserted: 65535,
+		},
+		{
+			name:      "canceled while waiting for limiter",
+			record:    10,
+			batchSize: 10,
+			limiter:   database.NewLimiter(1, 1),
+			timeout:   50 * time.Millisecond,
+			exec: func(context.Context, context.CancelFunc, int64) error {
+				return nil
+			},
+			execs:    0,
+			inserted: 0,
+		},
+	}
+
+	defer func(v string) { database.Progress = v }(database.Progress)
+	defer func(v *database.Limiter) { database.RowLimiter = v }(database.RowLimiter)
+
+	database.Progress = database.ProgressNone
+
+	for _, c := range cases {
+		database.RowLimiter = c.limiter
+
+		ctx, cancel := context.WithCancel(context.Background())
+		if c.timeout > 0 {
+			ctx, cancel = context.WithTimeout(context.Background(), c.timeout)
+		}
+
+		var execs atomic.Int64
+
+		connector := &fakeConnector{exec: func(ctx context.Context, _ int) error {
+			return c.exec(ctx, cancel, execs.Add(1))
+		}}
+		client := database.MySQLClient{DB: sqlx.NewDb(sql.OpenDB(connector), "mysql")}
+
+		cfg := &config.Table{
+			Name:        "table_a",
+			Columns:     []*config.Column{{Name: "col_1", Type: "int"}},
+			Record:      c.record,
+			BatchSize:   c.batchSize,
+			Concurrency: 1,
+		}
+
+		start := time.Now()
+		report, err := client.Populate(ctx, cfg)
+		cancel()
+
+		if !assert.ErrorIs(t, err, ctx.Err()) ||
+			!assert.Less(t, time.Since(start), time.Second) ||
+			!assert.Equal(t, c.execs, execs.Load()) ||
+			!assert.Equal(t, &database.Report{Inserted: c.inserted}, report) {
+			t.Errorf("case: %s is failed\n", c.name)
+		}
+
+		client.Close()
+	}
+}
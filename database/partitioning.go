@@ -0,0 +1,241 @@
+/*
+Package database ...
+
+Copyright © 2019 hajime-terasawa <terako.studio@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package database
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+
+	"github.com/terakoya76/populator/config"
+	"github.com/terakoya76/populator/rand"
+	"github.com/terakoya76/populator/utils"
+)
+
+const (
+	dateLayout     = "2006-01-02"
+	datetimeLayout = "2006-01-02 15:04:05"
+)
+
+// BuildPartitioningDesc generate a partition_options part of sql for MySQL w/ partitioning of the table.
+func (db *MySQLClient) BuildPartitioningDesc(table *config.Table) string {
+	var sb strings.Builder
+
+	cfg := table.Partitioning
+
+	typ := strings.ToUpper(cfg.Type)
+
+	switch typ {
+	case "HASH":
+		return fmt.Sprintf("\nPARTITION BY HASH (%s) PARTITIONS %d", cfg.Expr, cfg.Count)
+	case "KEY":
+		return fmt.Sprintf("\nPARTITION BY KEY (%s) PARTITIONS %d", quoteIdentifiers(cfg.Columns), cfg.Count)
+	default:
+	}
+
+	// COLUMNS partitioning accepts non-integer columns like date, so it is used for a plain column.
+	// Only timestamp is rejected by COLUMNS partitioning, which is partitioned by UNIX_TIMESTAMP instead.
+	name := cfg.Column()
+	column := table.Column(name)
+	timestamp := name != "" && column != nil && column.Type == "timestamp"
+
+	switch {
+	case timestamp:
+		sb.WriteString(fmt.Sprintf("\nPARTITION BY %s (UNIX_TIMESTAMP(%s)) (\n", typ, quoteIdentifier(name)))
+	case name != "":
+		sb.WriteString(fmt.Sprintf("\nPARTITION BY %s COLUMNS(%s) (\n", typ, quoteIdentifier(name)))
+	default:
+		sb.WriteString(fmt.Sprintf("\nPARTITION BY %s (%s) (\n", typ, cfg.Expr))
+	}
+
+	reg := make([]string, 0, len(cfg.Partitions))
+	for _, partition := range cfg.Partitions {
+		if typ == "LIST" {
+			values := make([]string, 0, len(partition.In))
+			for _, v := range partition.In {
+				values = append(values, partitionLiteral(v))
+			}
+
+			reg = append(reg, fmt.Sprintf("    PARTITION %s VALUES IN (%s)", quoteIdentifier(partition.Name), strings.Join(values, ", ")))
+
+			continue
+		}
+
+		bound := partitionLiteral(partition.LessThan)
+		if _, ok := partition.LessThan.(string); ok && timestamp && bound != config.MaxValue {
+			bound = fmt.Sprintf("UNIX_TIMESTAMP(%s)", bound)
+		}
+
+		reg = append(reg, fmt.Sprintf("    PARTITION %s VALUES LESS THAN (%s)", quoteIdentifier(partition.Name), bound))
+	}
+
+	sb.WriteString(strings.Join(reg, ",\n"))
+	sb.WriteString("\n)")
+
+	return sb.String()
+}
+
+func partitionLiteral(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		if strings.EqualFold(v, config.MaxValue) {
+			return config.MaxValue
+		}
+
+		return quoteString(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// generatePartitionValue picks a partition by weights, or equally w/o weights,
+// then returns a value of the partitioning column landing on it.
+func (db *MySQLClient) generatePartitionValue(cfg *config.Table) (string, interface{}, bool) {
+	p := cfg.Partitioning
+	if p == nil || len(p.Partitions) == 0 || !(strings.EqualFold(p.Type, "range") || strings.EqualFold(p.Type, "list")) {
+		return "", nil, false
+	}
+
+	column := cfg.Column(p.Column())
+	if column == nil || column.AutoIncrement {
+		return "", nil, false
+	}
+
+	idx := p.PickPartition()
+	partition := p.Partitions[idx]
+
+	if strings.EqualFold(p.Type, "list") {
+		return column.Name, utils.Shuffle(partition.In), true
+	}
+
+	var lower interface{}
+	if idx > 0 {
+		lower = p.Partitions[idx-1].LessThan
+	} else if p.From != "" {
+		lower = p.From
+	}
+
+	value, ok := rangeValue(column, lower, partition.LessThan)
+	if !ok {
+		return "", nil, false
+	}
+
+	return column.Name, value, true
+}
+
+// rangeValue returns a value of the column in [lower, upper), nil lower and MAXVALUE upper mean unbounded.
+func rangeValue(column *config.Column, lower, upper interface{}) (interface{}, bool) {
+	switch column.Type {
+	case "date", "datetime", "timestamp":
+		return dateRangeValue(column, lower, upper)
+	default:
+	}
+
+	minF, maxF, ok := integerBounds(column)
+	if !ok {
+		return nil, false
+	}
+
+	if hi, ok := numericBound(upper); ok {
+		maxF = hi - 1
+
+		// the first partition starts from zero rather than the min of the type, like ids
+		if lower == nil && hi > 0 {
+			minF = 0
+		}
+	}
+
+	if lo, ok := numericBound(lower); ok {
+		minF = lo
+	}
+
+	maxF = math.Min(maxF, math.MaxInt64)
+	if minF > maxF {
+		return nil, false
+	}
+
+	return rand.IntRange(int64(minF), int64(maxF)), true
+}
+
+func numericBound(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+func dateRangeValue(column *config.Column, lower, upper interface{}) (interface{}, bool) {
+	// https://dev.mysql.com/doc/refman/8.0/ja/datetime.html
+	minT := time.Date(1000, 1, 1, 0, 0, 0, 0, time.UTC)
+	maxT := time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC)
+
+	if column.Type == "timestamp" {
+		minT = time.Date(1970, 1, 1, 0, 0, 1, 0, time.UTC)
+		maxT = time.Date(2038, 1, 19, 3, 14, 7, 0, time.UTC)
+	}
+
+	if lo, ok := timeBound(lower); ok {
+		minT = lo
+	}
+
+	if hi, ok := timeBound(upper); ok {
+		maxT = hi.Add(-time.Second)
+	} else if lower != nil {
+		// MAXVALUE partition gets a year after the lower bound
+		maxT = minT.AddDate(1, 0, 0)
+	}
+
+	if maxT.Before(minT) {
+		return nil, false
+	}
+
+	t := gofakeit.DateRange(minT, maxT)
+	if column.Type == "date" {
+		return t.Format(dateLayout), true
+	}
+
+	return t.Format(datetimeLayout), true
+}
+
+func timeBound(v interface{}) (time.Time, bool) {
+	s, ok := v.(string)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	for _, layout := range []string{dateLayout, datetimeLayout} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
@@ -0,0 +1,239 @@
+/*
+Package cmd ...
+
+	Copyright © 2019 hajime-terasawa <terako.studio@gmail.com>
+	Licensed under the Apache License, Version 2.0 (the "License");
+
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	    http://www.apache.org/licenses/LICENSE-2.0
+	Unless required by applicable law or agreed to in writing, software
+
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package database_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/terakoya76/populator/config"
+	"github.com/terakoya76/populator/database"
+)
+
+func Test_RangeValue_Integer(t *testing.T) {
+	cases := []struct {
+		name   string
+		column *config.Column
+		lower  interface{}
+		upper  interface{}
+		min    int64
+		max    int64
+	}{
+		{
+			name:   "between bounds",
+			column: &config.Column{Name: "id", Type: "int"},
+			lower:  100,
+			upper:  200,
+			min:    100,
+			max:    199,
+		},
+		{
+			name:   "first partition starts from zero",
+			column: &config.Column{Name: "id", Type: "int"},
+			lower:  nil,
+			upper:  100,
+			min:    0,
+			max:    99,
+		},
+		{
+			name:   "maxvalue partition up to the max of the type",
+			column: &config.Column{Name: "id", Type: "tinyint", Unsigned: true},
+			lower:  200,
+			upper:  config.MaxValue,
+			min:    200,
+			max:    255,
+		},
+		{
+			name:   "bounds as strings",
+			column: &config.Column{Name: "id", Type: "bigint"},
+			lower:  "1000",
+			upper:  "1001",
+			min:    1000,
+			max:    1000,
+		},
+	}
+
+	for _, c := range cases {
+		for range 100 {
+			value, ok := database.RangeValue(c.column, c.lower, c.upper)
+			v, _ := value.(int64)
+
+			if !assert.True(t, ok) || !assert.True(t, v >= c.min && v <= c.max, v) {
+				t.Errorf("case: %s is failed\n", c.name)
+				break
+			}
+		}
+	}
+}
+
+func Test_RangeValue_Date(t *testing.T) {
+	cases := []struct {
+		name   string
+		column *config.Column
+		lower  interface{}
+		upper  interface{}
+		min    string
+		max    string
+	}{
+		{
+			name:   "date",
+			column: &config.Column{Name: "created_on", Type: "date"},
+			lower:  "2024-11-01",
+			upper:  "2024-12-01",
+			min:    "2024-11-01",
+			max:    "2024-11-30",
+		},
+		{
+			name:   "datetime",
+			column: &config.Column{Name: "created_at", Type: "datetime"},
+			lower:  "2024-11-01",
+			upper:  "2024-12-01",
+			min:    "2024-11-01 00:00:00",
+			max:    "2024-11-30 23:59:59",
+		},
+		{
+			name:   "timestamp",
+			column: &config.Column{Name: "created_at", Type: "timestamp"},
+			lower:  "2024-11-01 12:00:00",
+			upper:  "2024-11-01 13:00:00",
+			min:    "2024-11-01 12:00:00",
+			max:    "2024-11-01 12:59:59",
+		},
+		{
+			name:   "first partition starts from the min of the type",
+			column: &config.Column{Name: "created_at", Type: "timestamp"},
+			lower:  nil,
+			upper:  "2000-01-01",
+			min:    "1970-01-01 00:00:01",
+			max:    "1999-12-31 23:59:59",
+		},
+		{
+			name:   "maxvalue partition gets a year",
+			column: &config.Column{Name: "created_on", Type: "date"},
+			lower:  "2025-02-01",
+			upper:  config.MaxValue,
+			min:    "2025-02-01",
+			max:    "2026-02-01",
+		},
+	}
+
+	for _, c := range cases {
+		for range 100 {
+			value, ok := database.RangeValue(c.column, c.lower, c.upper)
+			v, _ := value.(string)
+
+			// dates and datetimes in the fixed layout are ordered as strings
+			if !assert.True(t, ok) || !assert.True(t, v >= c.min && v <= c.max, v) {
+				t.Errorf("case: %s is failed\n", c.name)
+				break
+			}
+		}
+	}
+}
+
+func Test_GeneratePartitionValue_Unweighted(t *testing.T) {
+	cases := []struct {
+		name         string
+		table        *config.Table
+		partitioned  bool
+		withinBounds func(v interface{}) bool
+	}{
+		{
+			name: "list",
+			table: &config.Table{
+				Name:    "table_a",
+				Columns: []*config.Column{{Name: "region", Type: "varchar", Order: 10}},
+				Partitioning: &config.Partitioning{
+					Type: "list",
+					Expr: "region",
+					Partitions: []*config.Partition{
+						{Name: "p_east", In: []interface{}{"tokyo", "sendai"}},
+						{Name: "p_west", In: []interface{}{"osaka"}},
+					},
+				},
+			},
+			partitioned: true,
+			withinBounds: func(v interface{}) bool {
+				return v == "tokyo" || v == "sendai" || v == "osaka"
+			},
+		},
+		{
+			name: "range by interval w/o maxValue",
+			table: &config.Table{
+				Name:    "table_a",
+				Columns: []*config.Column{{Name: "created_on", Type: "date"}},
+				Partitioning: &config.Partitioning{
+					Type:     "range",
+					Expr:     "created_on",
+					Interval: "month",
+					From:     "2024-11-01",
+					To:       "2025-02-01",
+				},
+			},
+			partitioned: true,
+			withinBounds: func(v interface{}) bool {
+				s, _ := v.(string)
+				return s >= "2024-11-01" && s < "2025-02-01"
+			},
+		},
+		{
+			name: "range over expression",
+			table: &config.Table{
+				Name:    "table_a",
+				Columns: []*config.Column{{Name: "created_on", Type: "date"}},
+				Partitioning: &config.Partitioning{
+					Type: "range",
+					Expr: "YEAR(created_on)",
+					Partitions: []*config.Partition{
+						{Name: "p2024", LessThan: 2025},
+					},
+				},
+			},
+			partitioned: false,
+		},
+		{
+			name: "hash",
+			table: &config.Table{
+				Name:    "table_a",
+				Columns: []*config.Column{{Name: "id", Type: "int"}},
+				Partitioning: &config.Partitioning{
+					Type:  "hash",
+					Expr:  "id",
+					Count: 4,
+				},
+			},
+			partitioned: false,
+		},
+	}
+
+	client := database.MySQLClient{}
+
+	for _, c := range cases {
+		c.table.Partitioning.CompleteWithDefault()
+
+		for range 100 {
+			_, value, partitioned := client.GeneratePartitionValue(c.table)
+
+			if !assert.Equal(t, c.partitioned, partitioned) || (partitioned && !assert.True(t, c.withinBounds(value), value)) {
+				t.Errorf("case: %s is failed\n", c.name)
+				break
+			}
+		}
+	}
+}
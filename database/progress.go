@@ -0,0 +1,294 @@
+/*
+Package database ...
+
+Copyright © 2019 hajime-terasawa <terako.studio@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package database
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/terakoya76/populator/config"
+)
+
+const (
+	// ProgressAuto shows a live bar on a terminal, and log lines otherwise or w/ verbose output.
+	ProgressAuto = "auto"
+	// ProgressBar shows a live bar.
+	ProgressBar = "bar"
+	// ProgressLog shows a log line per ProgressInterval.
+	ProgressLog = "log"
+	// ProgressNone shows nothing.
+	ProgressNone = "none"
+)
+
+// Progress is how the progress of populating tables is shown.
+var Progress = ProgressAuto
+
+// ProgressInterval is the interval of log lines of the progress.
+var ProgressInterval = 10 * time.Second
+
+const (
+	barInterval = 200 * time.Millisecond
+	barWidth    = 30
+)
+
+// ValidateProgress validates the mode and the log interval of the progress.
+func ValidateProgress(mode string, interval time.Duration) error {
+	switch mode {
+	case ProgressAuto, ProgressBar, ProgressLog, ProgressNone:
+	default:
+		return fmt.Errorf("progress %q is invalid, it must be one of auto, bar, log and none", mode)
+	}
+
+	if interval <= 0 {
+		return errors.New("progress interval must be positive")
+	}
+
+	return nil
+}
+
+// ProgressStat is a snapshot of populating a table.
+type ProgressStat struct {
+	Table    string
+	Total    int
+	Inserted int
+	Failed   int
+	Retries  int
+	Bytes    int64
+	Elapsed  time.Duration
+}
+
+// Done returns the number of processed records, whether they are inserted or failed.
+func (s *ProgressStat) Done() int {
+	return s.Inserted + s.Failed
+}
+
+// RowsPerSec returns the average throughput of records.
+func (s *ProgressStat) RowsPerSec() float64 {
+	if s.Elapsed <= 0 {
+		return 0
+	}
+
+	return float64(s.Done()) / s.Elapsed.Seconds()
+}
+
+// MBPerSec returns the average throughput of estimated bytes sent.
+func (s *ProgressStat) MBPerSec() float64 {
+	if s.Elapsed <= 0 {
+		return 0
+	}
+
+	return float64(s.Bytes) / 1e6 / s.Elapsed.Seconds()
+}
+
+// ETA returns the estimated time to process the rest, empty means unknown yet.
+func (s *ProgressStat) ETA() string {
+	rate := s.RowsPerSec()
+	if rate == 0 {
+		return "-"
+	}
+
+	rest := max(s.Total-s.Done(), 0)
+
+	return (time.Duration(float64(rest) / rate * float64(time.Second))).Round(time.Second).String()
+}
+
+func (s *ProgressStat) percent() int {
+	if s.Total <= 0 {
+		return 100
+	}
+
+	return min(s.Done()*100/s.Total, 100)
+}
+
+// Line formats the stat as a log line.
+func (s *ProgressStat) Line() string {
+	return fmt.Sprintf(
+		"table %s: %d/%d records (%d%%), %.0f rows/s, %.1f MB/s, ETA %s, %d errors, %d retries",
+		s.Table, s.Done(), s.Total, s.percent(), s.RowsPerSec(), s.MBPerSec(), s.ETA(), s.Failed, s.Retries,
+	)
+}
+
+// Bar formats the stat as a progress bar.
+func (s *ProgressStat) Bar() string {
+	filled := s.percent() * barWidth / 100
+
+	bar := strings.Repeat("=", filled)
+	if filled < barWidth {
+		bar += ">" + strings.Repeat(" ", barWidth-filled-1)
+	}
+
+	return fmt.Sprintf(
+		"%s [%s] %3d%% %d/%d %.0f rows/s %.1f MB/s ETA %s errors %d retries %d",
+		s.Table, bar, s.percent(), s.Done(), s.Total, s.RowsPerSec(), s.MBPerSec(), s.ETA(), s.Failed, s.Retries,
+	)
+}
+
+// output serializes writes to stdout, so that messages don't break the progress bar.
+var output struct {
+	sync.Mutex
+	bar bool
+}
+
+// printf prints a message, clearing the progress bar shown if any, which is redrawn on the next tick.
+func printf(format string, args ...interface{}) {
+	output.Lock()
+	defer output.Unlock()
+
+	if output.bar {
+		fmt.Print("\r\033[K")
+	}
+
+	fmt.Printf(format, args...)
+}
+
+// progress shows the progress of populating a table periodically.
+type progress struct {
+	table  string
+	total  int
+	result *populateResult
+	start  time.Time
+	bar    bool
+	out    io.Writer
+	done   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// progressMode resolves auto to bar or log, which is replaced w/ log under verbose,
+// since the printed sql would break the bar.
+func progressMode(mode string, terminal, verbose bool) string {
+	if mode == ProgressAuto {
+		mode = ProgressLog
+		if terminal {
+			mode = ProgressBar
+		}
+	}
+
+	if mode == ProgressBar && verbose {
+		return ProgressLog
+	}
+
+	return mode
+}
+
+// startProgress starts showing the progress of populating the table, nil means nothing is shown.
+func startProgress(cfg *config.Table, result *populateResult) *progress {
+	mode := progressMode(Progress, isTerminal(os.Stdout), Verbose)
+
+	if mode == ProgressNone {
+		return nil
+	}
+
+	p := &progress{
+		table:  cfg.Name,
+		total:  cfg.Record,
+		result: result,
+		start:  time.Now(),
+		bar:    mode == ProgressBar,
+		out:    os.Stdout,
+		done:   make(chan struct{}),
+	}
+
+	interval := ProgressInterval
+	if p.bar {
+		interval = barInterval
+
+		output.Lock()
+		output.bar = true
+		output.Unlock()
+	}
+
+	p.wg.Add(1)
+
+	go func() {
+		defer p.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-p.done:
+				return
+			case <-ticker.C:
+				p.render(false)
+			}
+		}
+	}()
+
+	return p
+}
+
+// stop stops showing the progress, then shows the final one.
+func (p *progress) stop() {
+	if p == nil {
+		return
+	}
+
+	close(p.done)
+	p.wg.Wait()
+
+	p.render(true)
+
+	if p.bar {
+		output.Lock()
+		output.bar = false
+		output.Unlock()
+	}
+}
+
+func (p *progress) render(final bool) {
+	report := p.result.report()
+	stat := &ProgressStat{
+		Table:    p.table,
+		Total:    p.total,
+		Inserted: report.Inserted,
+		Failed:   report.Failed,
+		Retries:  report.Retries,
+		Bytes:    p.result.sentBytes(),
+		Elapsed:  time.Since(p.start),
+	}
+
+	output.Lock()
+	defer output.Unlock()
+
+	if !p.bar {
+		fmt.Fprintln(p.out, stat.Line())
+		return
+	}
+
+	fmt.Fprint(p.out, "\r\033[K"+stat.Bar())
+
+	if final {
+		fmt.Fprintln(p.out)
+	}
+}
+
+// isTerminal returns whether the file is a character device like a terminal.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
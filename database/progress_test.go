@@ -0,0 +1,112 @@
+/*
+Package database ...
+
+Copyright © 2019 hajime-terasawa <terako.studio@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package database_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/terakoya76/populator/database"
+)
+
+func Test_ProgressStat(t *testing.T) {
+	cases := []struct {
+		name string
+		stat *database.ProgressStat
+		line string
+		bar  string
+	}{
+		{
+			name: "started",
+			stat: &database.ProgressStat{Table: "table_a", Total: 1000},
+			line: "table table_a: 0/1000 records (0%), 0 rows/s, 0.0 MB/s, ETA -, 0 errors, 0 retries",
+			bar:  "table_a [>                             ]   0% 0/1000 0 rows/s 0.0 MB/s ETA - errors 0 retries 0",
+		},
+		{
+			name: "in progress",
+			stat: &database.ProgressStat{
+				Table:    "table_a",
+				Total:    1000,
+				Inserted: 400,
+				Failed:   100,
+				Retries:  3,
+				Bytes:    5_000_000,
+				Elapsed:  2 * time.Second,
+			},
+			line: "table table_a: 500/1000 records (50%), 250 rows/s, 2.5 MB/s, ETA 2s, 100 errors, 3 retries",
+			bar:  "table_a [===============>              ]  50% 500/1000 250 rows/s 2.5 MB/s ETA 2s errors 100 retries 3",
+		},
+		{
+			name: "finished",
+			stat: &database.ProgressStat{Table: "table_a", Total: 1000, Inserted: 1000, Bytes: 1_000_000, Elapsed: time.Second},
+			line: "table table_a: 1000/1000 records (100%), 1000 rows/s, 1.0 MB/s, ETA 0s, 0 errors, 0 retries",
+			bar:  "table_a [==============================] 100% 1000/1000 1000 rows/s 1.0 MB/s ETA 0s errors 0 retries 0",
+		},
+	}
+
+	for _, c := range cases {
+		if !assert.Equal(t, c.line, c.stat.Line()) || !assert.Equal(t, c.bar, c.stat.Bar()) {
+			t.Errorf("case: %s is failed\n", c.name)
+		}
+	}
+}
+
+func Test_ValidateProgress(t *testing.T) {
+	cases := []struct {
+		name     string
+		mode     string
+		interval time.Duration
+		err      bool
+	}{
+		{name: "auto", mode: "auto", interval: time.Second},
+		{name: "none", mode: "none", interval: time.Second},
+		{name: "unknown mode", mode: "json", interval: time.Second, err: true},
+		{name: "zero interval", mode: "log", interval: 0, err: true},
+	}
+
+	for _, c := range cases {
+		if !assert.Equal(t, c.err, database.ValidateProgress(c.mode, c.interval) != nil) {
+			t.Errorf("case: %s is failed\n", c.name)
+		}
+	}
+}
+
+func Test_ProgressMode(t *testing.T) {
+	cases := []struct {
+		name     string
+		mode     string
+		terminal bool
+		verbose  bool
+		result   string
+	}{
+		{name: "auto on terminal", mode: database.ProgressAuto, terminal: true, result: database.ProgressBar},
+		{name: "auto on pipe", mode: database.ProgressAuto, terminal: false, result: database.ProgressLog},
+		{name: "auto w/ verbose", mode: database.ProgressAuto, terminal: true, verbose: true, result: database.ProgressLog},
+		{name: "bar w/ verbose", mode: database.ProgressBar, terminal: true, verbose: true, result: database.ProgressLog},
+		{name: "bar on pipe", mode: database.ProgressBar, terminal: false, result: database.ProgressBar},
+		{name: "none w/ verbose", mode: database.ProgressNone, terminal: true, verbose: true, result: database.ProgressNone},
+	}
+
+	for _, c := range cases {
+		if !assert.Equal(t, c.result, database.ProgressMode(c.mode, c.terminal, c.verbose)) {
+			t.Errorf("case: %s is failed\n", c.name)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Package database ...
+
+Copyright © 2019 hajime-terasawa <terako.studio@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	mrand "math/rand/v2"
+	"net"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+
+	"github.com/terakoya76/populator/config"
+)
+
+// retryableErrorNumbers are MySQL error numbers which may succeed on retry.
+var retryableErrorNumbers = map[uint16]bool{
+	1040: true, // ER_CON_COUNT_ERROR: too many connections
+	1205: true, // ER_LOCK_WAIT_TIMEOUT
+	1213: true, // ER_LOCK_DEADLOCK
+}
+
+// IsRetryable returns whether the error is transient and the statement is known not to be applied.
+// driver.ErrBadConn is returned only when the statement has not been sent yet.
+func IsRetryable(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return retryableErrorNumbers[mysqlErr.Number]
+	}
+
+	return errors.Is(err, driver.ErrBadConn)
+}
+
+// IsAmbiguous returns whether the connection is lost after the statement is sent.
+// The statement may have been committed, so it is never retried, which would duplicate the records.
+func IsAmbiguous(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) {
+		return false
+	}
+
+	if errors.Is(err, mysql.ErrInvalidConn) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var netErr net.Error
+
+	return errors.As(err, &netErr)
+}
+
+// Backoff returns the wait before the next attempt, exponential backoff w/ full jitter.
+func Backoff(cfg *config.Retry, attempt int) time.Duration {
+	limit := cfg.Backoff
+	for i := 1; i < attempt && limit < cfg.MaxBackoff; i++ {
+		limit *= 2
+	}
+
+	limit = min(limit, cfg.MaxBackoff)
+	if limit <= 0 {
+		return 0
+	}
+
+	return time.Duration(mrand.Int64N(int64(limit) + 1))
+}
+
+// shouldRetry returns whether the failed statement is retried under the policy.
+// Under the retry policy, the other errors than transient ones are retried as well, except for ambiguous ones.
+func shouldRetry(onError string, err error) bool {
+	return IsRetryable(err) || (onError == config.OnErrorRetry && !IsAmbiguous(err))
+}
+
+// execWithRetry executes an insert statement, which is retried on transient errors.
+func (db *MySQLClient) execWithRetry(ctx context.Context, cfg *config.Table, rows [][]interface{}, result *populateResult) error {
+	retry := cfg.Retry
+	if retry == nil {
+		retry = &config.Retry{}
+		retry.CompleteWithDefault()
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := db.execInsertStmt(ctx, cfg, rows)
+		if err == nil {
+			return nil
+		}
+
+		if ctx.Err() != nil || attempt >= retry.MaxAttempts || !shouldRetry(cfg.OnError, err) {
+			return err
+		}
+
+		result.retried()
+
+		if err := sleep(ctx, Backoff(retry, attempt)); err != nil {
+			return err
+		}
+	}
+}
+
+// sleep pauses for the duration, then returns the error of ctx when it is canceled meanwhile.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,203 @@
+/*
+Package database ...
+
+Copyright © 2019 hajime-terasawa <terako.studio@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package database_test
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/terakoya76/populator/config"
+	"github.com/terakoya76/populator/database"
+)
+
+func Test_IsRetryable(t *testing.T) {
+	cases := []struct {
+		name   string
+		err    error
+		result bool
+	}{
+		{
+			name:   "deadlock",
+			err:    &mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"},
+			result: true,
+		},
+		{
+			name:   "lock wait timeout",
+			err:    fmt.Errorf("wrapped: %w", &mysql.MySQLError{Number: 1205}),
+			result: true,
+		},
+		{
+			name:   "too many connections",
+			err:    &mysql.MySQLError{Number: 1040},
+			result: true,
+		},
+		{
+			name:   "dropped connection",
+			err:    mysql.ErrInvalidConn,
+			result: false,
+		},
+		{
+			name:   "unexpected EOF",
+			err:    io.ErrUnexpectedEOF,
+			result: false,
+		},
+		{
+			name:   "server lost",
+			err:    &mysql.MySQLError{Number: 2013},
+			result: false,
+		},
+		{
+			name:   "bad connection",
+			err:    driver.ErrBadConn,
+			result: true,
+		},
+		{
+			name:   "duplicate entry",
+			err:    &mysql.MySQLError{Number: 1062},
+			result: false,
+		},
+		{
+			name:   "other error",
+			err:    errors.New("unknown"),
+			result: false,
+		},
+	}
+
+	for _, c := range cases {
+		if !assert.Equal(t, c.result, database.IsRetryable(c.err)) {
+			t.Errorf("case: %s is failed\n", c.name)
+		}
+	}
+}
+
+func Test_IsAmbiguous(t *testing.T) {
+	cases := []struct {
+		name   string
+		err    error
+		result bool
+	}{
+		{
+			name:   "dropped connection",
+			err:    fmt.Errorf("wrapped: %w", mysql.ErrInvalidConn),
+			result: true,
+		},
+		{
+			name:   "unexpected EOF",
+			err:    io.ErrUnexpectedEOF,
+			result: true,
+		},
+		{
+			name:   "network error",
+			err:    &net.OpError{Op: "read", Err: errors.New("connection reset by peer")},
+			result: true,
+		},
+		{
+			name:   "bad connection",
+			err:    driver.ErrBadConn,
+			result: false,
+		},
+		{
+			name:   "deadlock",
+			err:    &mysql.MySQLError{Number: 1213},
+			result: false,
+		},
+	}
+
+	for _, c := range cases {
+		if !assert.Equal(t, c.result, database.IsAmbiguous(c.err)) {
+			t.Errorf("case: %s is failed\n", c.name)
+		}
+	}
+}
+
+func Test_ShouldRetry(t *testing.T) {
+	cases := []struct {
+		name    string
+		onError string
+		err     error
+		result  bool
+	}{
+		{
+			name:    "transient error under abort",
+			onError: config.OnErrorAbort,
+			err:     &mysql.MySQLError{Number: 1213},
+			result:  true,
+		},
+		{
+			name:    "other error under abort",
+			onError: config.OnErrorAbort,
+			err:     &mysql.MySQLError{Number: 1062},
+			result:  false,
+		},
+		{
+			name:    "other error under retry",
+			onError: config.OnErrorRetry,
+			err:     &mysql.MySQLError{Number: 1062},
+			result:  true,
+		},
+		{
+			name:    "dropped connection under retry",
+			onError: config.OnErrorRetry,
+			err:     mysql.ErrInvalidConn,
+			result:  false,
+		},
+		{
+			name:    "bad connection under skip",
+			onError: config.OnErrorSkip,
+			err:     driver.ErrBadConn,
+			result:  true,
+		},
+	}
+
+	for _, c := range cases {
+		if !assert.Equal(t, c.result, database.ShouldRetry(c.onError, c.err)) {
+			t.Errorf("case: %s is failed\n", c.name)
+		}
+	}
+}
+
+func Test_Backoff(t *testing.T) {
+	cfg := &config.Retry{MaxAttempts: 5, Backoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	cases := []struct {
+		attempt int
+		limit   time.Duration
+	}{
+		{attempt: 1, limit: 100 * time.Millisecond},
+		{attempt: 2, limit: 200 * time.Millisecond},
+		{attempt: 3, limit: 400 * time.Millisecond},
+		{attempt: 10, limit: time.Second},
+	}
+
+	for _, c := range cases {
+		for i := 0; i < 100; i++ {
+			d := database.Backoff(cfg, c.attempt)
+			if d < 0 || d > c.limit {
+				t.Errorf("backoff of attempt %d is %s, exceeds %s\n", c.attempt, d, c.limit)
+			}
+		}
+	}
+}
@@ -0,0 +1,563 @@
+/*
+Package database ...
+
+Copyright © 2019 hajime-terasawa <terako.studio@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package database
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/terakoya76/populator/config"
+	"github.com/terakoya76/populator/utils"
+)
+
+// TableSchema represents the live definition of a table read from information_schema.
+type TableSchema struct {
+	Columns []*ColumnSchema
+	Indexes []*IndexSchema
+}
+
+// ColumnSchema represents a row of information_schema.COLUMNS.
+type ColumnSchema struct {
+	Name          string
+	ColumnType    string
+	Nullable      bool
+	AutoIncrement bool
+	// Default is COLUMN_DEFAULT, nil means no default.
+	Default   *string
+	Collation string
+	Comment   string
+}
+
+// IndexSchema represents an index aggregated from rows of information_schema.STATISTICS.
+type IndexSchema struct {
+	Name    string
+	Uniq    bool
+	Type    string
+	Columns []string
+	// Lengths are prefix lengths of the key parts, 0 means the whole column.
+	Lengths []int
+	// Desc are whether the key parts are sorted in descending order.
+	Desc      []bool
+	Invisible bool
+}
+
+// FetchTableSchema reads the live definition of the table, nil means the table does not exist.
+func (db *MySQLClient) FetchTableSchema(ctx context.Context, cfg *config.Table) (*TableSchema, error) {
+	schema := &TableSchema{}
+
+	var columns []struct {
+		Name       string  `db:"COLUMN_NAME"`
+		ColumnType string  `db:"COLUMN_TYPE"`
+		Nullable   string  `db:"IS_NULLABLE"`
+		Extra      string  `db:"EXTRA"`
+		Default    *string `db:"COLUMN_DEFAULT"`
+		Collation  *string `db:"COLLATION_NAME"`
+		Comment    string  `db:"COLUMN_COMMENT"`
+	}
+
+	err := db.SelectContext(ctx, &columns, `SELECT COLUMN_NAME, COLUMN_TYPE, IS_NULLABLE, EXTRA, COLUMN_DEFAULT, COLLATION_NAME, COLUMN_COMMENT
+FROM information_schema.COLUMNS
+WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?
+ORDER BY ORDINAL_POSITION`, cfg.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(columns) == 0 {
+		return nil, nil
+	}
+
+	for _, c := range columns {
+		collation := ""
+		if c.Collation != nil {
+			collation = *c.Collation
+		}
+
+		schema.Columns = append(schema.Columns, &ColumnSchema{
+			Name:          c.Name,
+			ColumnType:    c.ColumnType,
+			Nullable:      c.Nullable == "YES",
+			AutoIncrement: strings.Contains(strings.ToLower(c.Extra), "auto_increment"),
+			Default:       c.Default,
+			Collation:     collation,
+			Comment:       c.Comment,
+		})
+	}
+
+	var stats []struct {
+		Name      string  `db:"INDEX_NAME"`
+		NonUnique int     `db:"NON_UNIQUE"`
+		Type      string  `db:"INDEX_TYPE"`
+		Column    *string `db:"COLUMN_NAME"`
+		SubPart   *int    `db:"SUB_PART"`
+		Collation *string `db:"COLLATION"`
+		Visible   *string `db:"IS_VISIBLE"`
+	}
+
+	// IS_VISIBLE is missing before MySQL 8.0, so all the columns are selected into the known fields
+	err = db.Unsafe().SelectContext(ctx, &stats, `SELECT *
+FROM information_schema.STATISTICS
+WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?
+ORDER BY INDEX_NAME, SEQ_IN_INDEX`, cfg.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := make(map[string]*IndexSchema)
+	for _, s := range stats {
+		index, ok := indexes[s.Name]
+		if !ok {
+			index = &IndexSchema{
+				Name:      s.Name,
+				Uniq:      s.NonUnique == 0,
+				Type:      s.Type,
+				Invisible: s.Visible != nil && *s.Visible == "NO",
+			}
+			indexes[s.Name] = index
+			schema.Indexes = append(schema.Indexes, index)
+		}
+
+		// functional key part has no column name
+		column := ""
+		if s.Column != nil {
+			column = *s.Column
+		}
+
+		length := 0
+		if s.SubPart != nil {
+			length = *s.SubPart
+		}
+
+		index.Columns = append(index.Columns, column)
+		index.Lengths = append(index.Lengths, length)
+		index.Desc = append(index.Desc, s.Collation != nil && *s.Collation == "D")
+	}
+
+	return schema, nil
+}
+
+// DiffTable compares the table config w/ the live table, then returns alter specifications to fill the gap.
+// Nothing is returned when the table does not exist, since it is just created.
+func (db *MySQLClient) DiffTable(ctx context.Context, cfg *config.Table) ([]string, error) {
+	schema, err := db.FetchTableSchema(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the definition of table %s: %w", cfg.Name, err)
+	}
+
+	if schema == nil {
+		return nil, nil
+	}
+
+	return db.BuildAlterSpecs(cfg, schema), nil
+}
+
+// AlterTable does AlterTable statement w/ given specifications for MySQL.
+func (db *MySQLClient) AlterTable(ctx context.Context, cfg *config.Table, specs []string) error {
+	if len(specs) == 0 {
+		return nil
+	}
+
+	sql := db.BuildAlterTableStmt(cfg, specs)
+
+	if Verbose {
+		fmt.Println(sql)
+	}
+
+	if _, err := db.ExecContext(ctx, sql); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// BuildAlterTableStmt generate alter_table_stmt sql for MySQL.
+func (db *MySQLClient) BuildAlterTableStmt(cfg *config.Table, specs []string) string {
+	return fmt.Sprintf("ALTER TABLE %s\n    %s", quoteIdentifier(cfg.Name), strings.Join(specs, ",\n    "))
+}
+
+// BuildAlterSpecs generate alter specifications turning the live schema into the table config.
+// Indexes are dropped first and added last, so that they never refer to dropped columns.
+func (db *MySQLClient) BuildAlterSpecs(cfg *config.Table, schema *TableSchema) []string {
+	dropIndexes, addIndexes := db.buildIndexSpecs(cfg, schema)
+	dropColumns, columns := db.buildColumnSpecs(cfg, schema)
+
+	specs := make([]string, 0, len(dropIndexes)+len(dropColumns)+len(columns)+len(addIndexes))
+	specs = append(specs, dropIndexes...)
+	specs = append(specs, dropColumns...)
+	specs = append(specs, columns...)
+	specs = append(specs, addIndexes...)
+
+	return specs
+}
+
+func (db *MySQLClient) buildColumnSpecs(cfg *config.Table, schema *TableSchema) (drops, changes []string) {
+	live := make(map[string]*ColumnSchema, len(schema.Columns))
+	for _, c := range schema.Columns {
+		live[strings.ToLower(c.Name)] = c
+	}
+
+	primary := primaryColumns(cfg)
+
+	declared := make(map[string]bool, len(cfg.Columns))
+	for _, column := range cfg.Columns {
+		declared[strings.ToLower(column.Name)] = true
+
+		// primary key is compared as an index, while its columns are not null
+		c := *column
+		c.NotNull = c.NotNull || primary[strings.ToLower(c.Name)]
+		c.Primary = false
+		desc := strings.TrimSpace(db.buildCreateTableStmtColumn(&c))
+
+		l, ok := live[strings.ToLower(column.Name)]
+		if !ok {
+			changes = append(changes, "ADD COLUMN "+desc)
+			continue
+		}
+
+		if !db.sameColumn(cfg, &c, l) {
+			changes = append(changes, "MODIFY COLUMN "+desc)
+		}
+	}
+
+	for _, c := range schema.Columns {
+		if !declared[strings.ToLower(c.Name)] {
+			drops = append(drops, "DROP COLUMN "+quoteIdentifier(c.Name))
+		}
+	}
+
+	return drops, changes
+}
+
+func (db *MySQLClient) buildIndexSpecs(cfg *config.Table, schema *TableSchema) (drops, adds []string) {
+	live := make(map[string]*IndexSchema, len(schema.Indexes))
+	for _, index := range schema.Indexes {
+		live[strings.ToLower(index.Name)] = index
+	}
+
+	declared := make(map[string]bool)
+	for _, e := range db.expectedIndexes(cfg) {
+		declared[strings.ToLower(e.schema.Name)] = true
+
+		l, ok := live[strings.ToLower(e.schema.Name)]
+		if ok && sameIndex(e.schema, l) {
+			continue
+		}
+
+		// changed index is re-created
+		if ok {
+			drops = append(drops, dropIndexSpec(l))
+		}
+
+		// unnamed index leaves a double space
+		adds = append(adds, "ADD "+strings.Replace(strings.TrimSpace(e.desc), "  (", " (", 1))
+	}
+
+	for _, l := range schema.Indexes {
+		if !declared[strings.ToLower(l.Name)] {
+			drops = append(drops, dropIndexSpec(l))
+		}
+	}
+
+	return drops, adds
+}
+
+// primaryColumns returns lower-cased names of columns in the primary key, declared on columns or indexes.
+func primaryColumns(cfg *config.Table) map[string]bool {
+	primary := make(map[string]bool)
+
+	for _, column := range cfg.Columns {
+		if column.Primary {
+			primary[strings.ToLower(column.Name)] = true
+		}
+	}
+
+	for _, index := range cfg.Indexes {
+		if !index.Primary {
+			continue
+		}
+
+		for _, part := range index.KeyParts() {
+			primary[strings.ToLower(part.Column)] = true
+		}
+	}
+
+	return primary
+}
+
+var (
+	displayWidthRe = regexp.MustCompile(
+		`^(tinyint|smallint|mediumint|int|bigint|year|tinyblob|blob|mediumblob|longblob|tinytext|text|mediumtext|longtext)\(\d+\)`,
+	)
+	lengthTypeRe = regexp.MustCompile(`^(text|blob)\((\d+)\)`)
+	realTypeRe   = regexp.MustCompile(`^real\b`)
+	spacesRe     = regexp.MustCompile(`\s*,\s*`)
+)
+
+// lengthTypes are the smallest text and blob types holding bytes up to the limits.
+var lengthTypes = []struct {
+	limit int
+	text  string
+	blob  string
+}{
+	{limit: 1<<8 - 1, text: "tinytext", blob: "tinyblob"},
+	{limit: 1<<16 - 1, text: "text", blob: "blob"},
+	{limit: 1<<24 - 1, text: "mediumtext", blob: "mediumblob"},
+}
+
+// normalizeColumnType turns the data type into the one MySQL holds, w/ charBytes the max bytes of a character.
+// It resolves aliases like boolean and real and the length of text, then drops display width of integer types.
+func normalizeColumnType(s string, charBytes int) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	switch s {
+	case "boolean", "bool":
+		s = "tinyint(1)"
+	default:
+	}
+
+	// real is double unless REAL_AS_FLOAT sql mode is enabled
+	s = realTypeRe.ReplaceAllString(s, "double")
+
+	// text(M) is the smallest text type holding M characters, and blob(M) holding M bytes
+	if m := lengthTypeRe.FindStringSubmatch(s); m != nil {
+		length, _ := strconv.Atoi(m[2])
+		if m[1] == "text" {
+			length *= charBytes
+		}
+
+		typ := "long" + m[1]
+
+		for _, t := range lengthTypes {
+			if length <= t.limit {
+				typ = t.blob
+				if m[1] == "text" {
+					typ = t.text
+				}
+
+				break
+			}
+		}
+
+		s = typ + s[len(m[0]):]
+	}
+
+	s = displayWidthRe.ReplaceAllString(s, "$1")
+
+	return spacesRe.ReplaceAllString(s, ",")
+}
+
+// sameColumn compares the column config w/ the live column.
+// Expression defaults and generated expressions are not compared, since MySQL rewrites them into its own notation.
+func (db *MySQLClient) sameColumn(table *config.Table, cfg *config.Column, live *ColumnSchema) bool {
+	charset := table.Charset
+	if cfg.Collate != "" {
+		charset, _, _ = strings.Cut(cfg.Collate, "_")
+	}
+
+	if normalizeColumnType(db.buildColumnType(cfg), config.CharsetMaxBytes(charset)) != normalizeColumnType(live.ColumnType, 1) {
+		return false
+	}
+
+	if cfg.NotNull == live.Nullable {
+		return false
+	}
+
+	autoIncrement := cfg.AutoIncrement && utils.Contains(IncrementableDataType, cfg.Type) && !cfg.IsGenerated()
+	if autoIncrement != live.AutoIncrement {
+		return false
+	}
+
+	if cfg.Collate != "" && !strings.EqualFold(cfg.Collate, live.Collation) {
+		return false
+	}
+
+	if !sameDefault(cfg.Default, live.Default) {
+		return false
+	}
+
+	return cfg.Comment == live.Comment
+}
+
+// sameDefault compares the default of the column config w/ COLUMN_DEFAULT.
+// Literal defaults are compared as numbers or times when both are, since MySQL formats them by the type, like 1.50.
+func sameDefault(expected interface{}, live *string) bool {
+	switch expected := expected.(type) {
+	case nil:
+		return live == nil
+	case string:
+		if live == nil {
+			return false
+		}
+
+		e, eErr := strconv.ParseFloat(expected, 64)
+		l, lErr := strconv.ParseFloat(*live, 64)
+		if eErr == nil && lErr == nil {
+			return e == l
+		}
+
+		eTime, eOk := timeBound(expected)
+		lTime, lOk := timeBound(*live)
+		if eOk && lOk {
+			return eTime.Equal(lTime)
+		}
+
+		return expected == *live
+	default:
+		// expression default
+		return live != nil
+	}
+}
+
+type expectedIndex struct {
+	schema *IndexSchema
+	desc   string
+}
+
+// expectedIndexes returns indexes which the table config declares, w/ the names MySQL gives.
+func (db *MySQLClient) expectedIndexes(cfg *config.Table) []*expectedIndex {
+	var (
+		expected []*expectedIndex
+		primary  []string
+	)
+
+	names := make(map[string]bool)
+	for _, index := range cfg.Indexes {
+		if index.Name != "" {
+			names[strings.ToLower(index.Name)] = true
+		}
+	}
+
+	for _, index := range cfg.Indexes {
+		schema := &IndexSchema{Name: index.Name, Uniq: index.Primary || index.Uniq, Invisible: index.Invisible}
+
+		for _, part := range index.KeyParts() {
+			schema.Columns = append(schema.Columns, part.Column)
+			schema.Lengths = append(schema.Lengths, part.Length)
+			schema.Desc = append(schema.Desc, strings.EqualFold(part.Order, "desc"))
+		}
+
+		switch {
+		case index.Primary:
+			schema.Name = "PRIMARY"
+		case schema.Name == "" && len(schema.Columns) > 0:
+			schema.Name = unnamedIndexName(schema.Columns[0], names)
+		default:
+		}
+
+		switch {
+		case index.Spatial:
+			schema.Type = "SPATIAL"
+		case index.Fulltext:
+			schema.Type = "FULLTEXT"
+		default:
+		}
+
+		expected = append(expected, &expectedIndex{schema: schema, desc: db.BuildIndexDesc(index)})
+	}
+
+	for _, column := range cfg.Columns {
+		if column.Primary {
+			primary = append(primary, column.Name)
+		}
+	}
+
+	if len(primary) > 0 {
+		expected = append(expected, &expectedIndex{
+			schema: &IndexSchema{Name: "PRIMARY", Uniq: true, Columns: primary},
+			desc:   fmt.Sprintf("PRIMARY KEY (%s)", quoteIdentifiers(primary)),
+		})
+	}
+
+	return expected
+}
+
+// unnamedIndexName returns the name MySQL gives to an unnamed index, which is its first column
+// or functional_index for an expression, suffixed w/ _2, _3 and so on when it is taken.
+func unnamedIndexName(column string, names map[string]bool) string {
+	base := column
+	if base == "" {
+		base = "functional_index"
+	}
+
+	name := base
+	for i := 2; names[strings.ToLower(name)]; i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+
+	names[strings.ToLower(name)] = true
+
+	return name
+}
+
+// sameIndex compares the expected index w/ the live index.
+// USING is not compared, since an engine may replace hash w/ btree.
+// Prefix lengths of spatial indexes are not compared either, since MySQL reports a fixed length for them.
+func sameIndex(expected, live *IndexSchema) bool {
+	if expected.Uniq != live.Uniq || indexKind(expected.Type) != indexKind(live.Type) || expected.Invisible != live.Invisible {
+		return false
+	}
+
+	if len(expected.Columns) != len(live.Columns) {
+		return false
+	}
+
+	for i := range expected.Columns {
+		if !strings.EqualFold(expected.Columns[i], live.Columns[i]) {
+			return false
+		}
+
+		spatial := indexKind(live.Type) == "SPATIAL"
+		if (!spatial && partAt(expected.Lengths, i) != partAt(live.Lengths, i)) || partAt(expected.Desc, i) != partAt(live.Desc, i) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// partAt returns the attribute of the i-th key part, which is zero value when unknown.
+func partAt[T any](attrs []T, i int) T {
+	var zero T
+	if i >= len(attrs) {
+		return zero
+	}
+
+	return attrs[i]
+}
+
+func dropIndexSpec(index *IndexSchema) string {
+	if strings.EqualFold(index.Name, "PRIMARY") {
+		return "DROP PRIMARY KEY"
+	}
+
+	return "DROP INDEX " + quoteIdentifier(index.Name)
+}
+
+// indexKind distinguishes spatial and fulltext indexes only, since an engine may replace hash w/ btree.
+func indexKind(typ string) string {
+	typ = strings.ToUpper(typ)
+	switch typ {
+	case "SPATIAL", "FULLTEXT":
+		return typ
+	default:
+		return ""
+	}
+}
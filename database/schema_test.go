@@ -0,0 +1,343 @@
+/*
+Package database ...
+
+Copyright © 2019 hajime-terasawa <terako.studio@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package database_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/terakoya76/populator/config"
+	"github.com/terakoya76/populator/database"
+)
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func Test_BuildAlterSpecs(t *testing.T) {
+	cases := []struct {
+		name   string
+		cfg    *config.Table
+		schema *database.TableSchema
+		result []string
+	}{
+		{
+			name: "no difference",
+			cfg: &config.Table{
+				Name: "table_a",
+				Columns: []*config.Column{
+					{Name: "id", Type: "bigint", Order: 20, Primary: true, AutoIncrement: true},
+					{Name: "name", Type: "varchar", Order: 64, NotNull: true},
+					{Name: "price", Type: "decimal", Order: 10, Precision: 2},
+				},
+				Indexes: []*config.Index{
+					{Name: "index_name", Columns: []string{"name"}},
+				},
+			},
+			schema: &database.TableSchema{
+				Columns: []*database.ColumnSchema{
+					{Name: "id", ColumnType: "bigint", AutoIncrement: true},
+					{Name: "name", ColumnType: "varchar(64)"},
+					{Name: "price", ColumnType: "decimal(10,2)", Nullable: true},
+				},
+				Indexes: []*database.IndexSchema{
+					{Name: "PRIMARY", Uniq: true, Type: "BTREE", Columns: []string{"id"}},
+					{Name: "index_name", Type: "BTREE", Columns: []string{"name"}},
+				},
+			},
+			result: []string{},
+		},
+
+		{
+			name: "add, modify and drop columns",
+			cfg: &config.Table{
+				Name: "table_a",
+				Columns: []*config.Column{
+					{Name: "id", Type: "bigint", Order: 20, Primary: true},
+					{Name: "name", Type: "varchar", Order: 128, NotNull: true},
+					{Name: "created_at", Type: "datetime"},
+				},
+			},
+			schema: &database.TableSchema{
+				Columns: []*database.ColumnSchema{
+					{Name: "id", ColumnType: "bigint(20)"},
+					{Name: "name", ColumnType: "varchar(64)"},
+					{Name: "deleted", ColumnType: "tinyint(1)", Nullable: true},
+				},
+				Indexes: []*database.IndexSchema{
+					{Name: "PRIMARY", Uniq: true, Type: "BTREE", Columns: []string{"id"}},
+				},
+			},
+			result: []string{
+				"DROP COLUMN `deleted`",
+				"MODIFY COLUMN `name` varchar(128) NOT NULL",
+				"ADD COLUMN `created_at` datetime",
+			},
+		},
+
+		{
+			name: "add, re-create and drop indexes",
+			cfg: &config.Table{
+				Name: "table_a",
+				Columns: []*config.Column{
+					{Name: "col_1", Type: "int", Order: 11},
+					{Name: "col_2", Type: "int", Order: 11},
+				},
+				Indexes: []*config.Index{
+					{Name: "index_1", Uniq: true, Columns: []string{"col_1"}},
+					{Columns: []string{"col_2"}},
+				},
+			},
+			schema: &database.TableSchema{
+				Columns: []*database.ColumnSchema{
+					{Name: "col_1", ColumnType: "int", Nullable: true},
+					{Name: "col_2", ColumnType: "int", Nullable: true},
+				},
+				Indexes: []*database.IndexSchema{
+					{Name: "index_1", Type: "BTREE", Columns: []string{"col_1"}},
+					{Name: "index_2", Type: "BTREE", Columns: []string{"col_1", "col_2"}},
+				},
+			},
+			result: []string{
+				"DROP INDEX `index_1`",
+				"DROP INDEX `index_2`",
+				"ADD UNIQUE `index_1` (`col_1`)",
+				"ADD INDEX (`col_2`)",
+			},
+		},
+
+		{
+			name: "no difference w/ aliases and primary index",
+			cfg: &config.Table{
+				Name:    "table_a",
+				Charset: "utf8mb4",
+				Columns: []*config.Column{
+					{Name: "id", Type: "bigint", Order: 20},
+					{Name: "active", Type: "boolean", NotNull: true},
+					{Name: "body", Type: "text", Order: 65535},
+					{Name: "data", Type: "blob", Order: 255},
+				},
+				Indexes: []*config.Index{
+					{Primary: true, Columns: []string{"id"}},
+				},
+			},
+			schema: &database.TableSchema{
+				Columns: []*database.ColumnSchema{
+					{Name: "id", ColumnType: "bigint"},
+					{Name: "active", ColumnType: "tinyint(1)"},
+					{Name: "body", ColumnType: "mediumtext", Nullable: true},
+					{Name: "data", ColumnType: "tinyblob", Nullable: true},
+				},
+				Indexes: []*database.IndexSchema{
+					{Name: "PRIMARY", Uniq: true, Type: "BTREE", Columns: []string{"id"}},
+				},
+			},
+			result: []string{},
+		},
+
+		{
+			name: "column of primary index turned nullable",
+			cfg: &config.Table{
+				Name: "table_a",
+				Columns: []*config.Column{
+					{Name: "id", Type: "bigint", Order: 20},
+				},
+				Indexes: []*config.Index{
+					{Primary: true, Columns: []string{"id"}},
+				},
+			},
+			schema: &database.TableSchema{
+				Columns: []*database.ColumnSchema{
+					{Name: "id", ColumnType: "bigint", Nullable: true},
+				},
+				Indexes: []*database.IndexSchema{
+					{Name: "PRIMARY", Uniq: true, Type: "BTREE", Columns: []string{"id"}},
+				},
+			},
+			result: []string{
+				"MODIFY COLUMN `id` bigint(20) NOT NULL",
+			},
+		},
+
+		{
+			name: "text length over the type",
+			cfg: &config.Table{
+				Name:    "table_a",
+				Charset: "utf8mb4",
+				Columns: []*config.Column{
+					{Name: "body", Type: "text", Order: 16383},
+				},
+			},
+			schema: &database.TableSchema{
+				Columns: []*database.ColumnSchema{
+					{Name: "body", ColumnType: "mediumtext", Nullable: true},
+				},
+			},
+			result: []string{
+				"MODIFY COLUMN `body` text(16383)",
+			},
+		},
+
+		{
+			name: "collation",
+			cfg: &config.Table{
+				Name: "table_a",
+				Columns: []*config.Column{
+					{Name: "name", Type: "varchar", Order: 64, Collate: "utf8mb4_bin"},
+					{Name: "code", Type: "varchar", Order: 8},
+				},
+			},
+			schema: &database.TableSchema{
+				Columns: []*database.ColumnSchema{
+					{Name: "name", ColumnType: "varchar(64)", Nullable: true, Collation: "utf8mb4_0900_ai_ci"},
+					{Name: "code", ColumnType: "varchar(8)", Nullable: true, Collation: "utf8mb4_0900_ai_ci"},
+				},
+			},
+			result: []string{
+				"MODIFY COLUMN `name` varchar(64) COLLATE utf8mb4_bin",
+			},
+		},
+
+		{
+			name: "prefix length, order and visibility of indexes",
+			cfg: &config.Table{
+				Name: "table_a",
+				Columns: []*config.Column{
+					{Name: "col_1", Type: "varchar", Order: 64},
+					{Name: "col_2", Type: "int", Order: 11},
+					{Name: "col_3", Type: "int", Order: 11},
+				},
+				Indexes: []*config.Index{
+					{Name: "index_1", Keys: []*config.KeyPart{{Column: "col_1", Length: 10}}},
+					{Name: "index_2", Keys: []*config.KeyPart{{Column: "col_2", Order: "desc"}}},
+					{Name: "index_3", Invisible: true, Columns: []string{"col_3"}},
+				},
+			},
+			schema: &database.TableSchema{
+				Columns: []*database.ColumnSchema{
+					{Name: "col_1", ColumnType: "varchar(64)", Nullable: true},
+					{Name: "col_2", ColumnType: "int", Nullable: true},
+					{Name: "col_3", ColumnType: "int", Nullable: true},
+				},
+				Indexes: []*database.IndexSchema{
+					{Name: "index_1", Type: "BTREE", Columns: []string{"col_1"}, Lengths: []int{20}},
+					{Name: "index_2", Type: "BTREE", Columns: []string{"col_2"}, Desc: []bool{false}},
+					{Name: "index_3", Type: "BTREE", Columns: []string{"col_3"}},
+				},
+			},
+			result: []string{
+				"DROP INDEX `index_1`",
+				"DROP INDEX `index_2`",
+				"DROP INDEX `index_3`",
+				"ADD INDEX `index_1` (`col_1`(10))",
+				"ADD INDEX `index_2` (`col_2` DESC)",
+				"ADD INDEX `index_3` (`col_3`) INVISIBLE",
+			},
+		},
+
+		{
+			name: "real is double",
+			cfg: &config.Table{
+				Name: "table_a",
+				Columns: []*config.Column{
+					{Name: "col_1", Type: "real", Order: 10, Precision: 2},
+				},
+			},
+			schema: &database.TableSchema{
+				Columns: []*database.ColumnSchema{
+					{Name: "col_1", ColumnType: "double(10,2)", Nullable: true},
+				},
+			},
+			result: []string{},
+		},
+
+		{
+			name: "unnamed functional indexes",
+			cfg: &config.Table{
+				Name: "table_a",
+				Columns: []*config.Column{
+					{Name: "col_1", Type: "varchar", Order: 64},
+				},
+				Indexes: []*config.Index{
+					{Keys: []*config.KeyPart{{Expr: "lower(col_1)"}}},
+					{Keys: []*config.KeyPart{{Expr: "upper(col_1)"}}},
+					{Columns: []string{"col_1"}},
+					{Columns: []string{"col_1"}},
+				},
+			},
+			schema: &database.TableSchema{
+				Columns: []*database.ColumnSchema{
+					{Name: "col_1", ColumnType: "varchar(64)", Nullable: true},
+				},
+				Indexes: []*database.IndexSchema{
+					{Name: "functional_index", Type: "BTREE", Columns: []string{""}},
+					{Name: "functional_index_2", Type: "BTREE", Columns: []string{""}},
+					{Name: "col_1", Type: "BTREE", Columns: []string{"col_1"}},
+					{Name: "col_1_2", Type: "BTREE", Columns: []string{"col_1"}},
+				},
+			},
+			result: []string{},
+		},
+
+		{
+			name: "literal defaults",
+			cfg: &config.Table{
+				Name: "table_a",
+				Columns: []*config.Column{
+					{Name: "col_1", Type: "decimal", Order: 5, Precision: 2, Default: "1.5"},
+					{Name: "col_2", Type: "datetime", Default: "2024-01-01"},
+					{Name: "col_3", Type: "varchar", Order: 10, Default: "new"},
+					{Name: "col_4", Type: "varchar", Order: 10},
+					{Name: "col_5", Type: "varchar", Order: 10, Default: "new"},
+				},
+			},
+			schema: &database.TableSchema{
+				Columns: []*database.ColumnSchema{
+					{Name: "col_1", ColumnType: "decimal(5,2)", Nullable: true, Default: stringPtr("1.50")},
+					{Name: "col_2", ColumnType: "datetime", Nullable: true, Default: stringPtr("2024-01-01 00:00:00")},
+					{Name: "col_3", ColumnType: "varchar(10)", Nullable: true, Default: stringPtr("old")},
+					{Name: "col_4", ColumnType: "varchar(10)", Nullable: true, Default: stringPtr("old")},
+					{Name: "col_5", ColumnType: "varchar(10)", Nullable: true},
+				},
+			},
+			result: []string{
+				"MODIFY COLUMN `col_3` varchar(10) DEFAULT \"new\"",
+				"MODIFY COLUMN `col_4` varchar(10)",
+				"MODIFY COLUMN `col_5` varchar(10) DEFAULT \"new\"",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		client := database.MySQLClient{}
+
+		result := client.BuildAlterSpecs(c.cfg, c.schema)
+		if !assert.Equal(t, c.result, result) {
+			t.Errorf("case: %s is failed, expected: %+v, actual: %+v\n", c.name, c.result, result)
+		}
+	}
+}
+
+func Test_BuildAlterTableStmt(t *testing.T) {
+	client := database.MySQLClient{}
+	cfg := &config.Table{Name: "table_a"}
+
+	result := client.BuildAlterTableStmt(cfg, []string{"DROP COLUMN `col_1`", "ADD COLUMN `col_2` int"})
+	assert.Equal(t, "ALTER TABLE `table_a`\n    DROP COLUMN `col_1`,\n    ADD COLUMN `col_2` int", result)
+}
@@ -0,0 +1,323 @@
+/*
+Package database ...
+
+Copyright © 2019 hajime-terasawa <terako.studio@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/terakoya76/populator/config"
+)
+
+// Throttler pauses workers while the server or its replicas exceed thresholds of the throttle config.
+type Throttler struct {
+	cfg      *config.Throttle
+	db       *sqlx.DB
+	replicas []*replica
+
+	mu        sync.Mutex
+	checked   time.Time
+	throttled string
+}
+
+type replica struct {
+	addr string
+	db   *sqlx.DB
+}
+
+// NewThrottler returns Throttler checking the server of db and the replicas of the config, nil config means no throttling.
+// The configured status and replicas are verified once, so that a misconfiguration fails here instead of throttling forever.
+func NewThrottler(ctx context.Context, db *sqlx.DB, cfg *config.Throttle) (*Throttler, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	t := &Throttler{cfg: cfg, db: db}
+
+	for _, dsn := range cfg.Replicas {
+		dsnCfg, err := mysql.ParseDSN(dsn)
+		if err != nil {
+			t.Close()
+			return nil, fmt.Errorf("replica dsn is invalid: %w", err)
+		}
+
+		conn, err := sqlx.Open("mysql", dsn)
+		if err != nil {
+			t.Close()
+			return nil, fmt.Errorf("failed to connect replica %s: %w", dsnCfg.Addr, err)
+		}
+
+		conn.SetMaxOpenConns(1)
+		t.replicas = append(t.replicas, &replica{addr: dsnCfg.Addr, db: conn})
+	}
+
+	if err := t.verify(ctx); err != nil {
+		t.Close()
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// verify checks that the configured status exists and the replicas report their lag.
+func (t *Throttler) verify(ctx context.Context) error {
+	if t.cfg.MaxThreadsRunning > 0 || len(t.cfg.Status) > 0 {
+		status, err := t.globalStatus(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to read global status: %w", err)
+		}
+
+		if err := ValidateStatus(t.cfg, status); err != nil {
+			return err
+		}
+	}
+
+	for _, r := range t.replicas {
+		if _, err := replicaLag(ctx, r.db); err != nil {
+			return fmt.Errorf("failed to read lag of replica %s: %w", r.addr, err)
+		}
+	}
+
+	return nil
+}
+
+// Close closes connections to the replicas.
+func (t *Throttler) Close() {
+	if t == nil {
+		return
+	}
+
+	for _, r := range t.replicas {
+		r.db.Close()
+	}
+}
+
+// Wait checks the thresholds at most once per interval, then blocks while any of them is exceeded.
+// Workers calling it meanwhile wait for the check, so all of them pause together.
+// It returns the error of ctx when it is canceled while throttled.
+func (t *Throttler) Wait(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if time.Since(t.checked) < t.cfg.Interval {
+		return nil
+	}
+
+	for {
+		reason := t.check(ctx)
+		t.checked = time.Now()
+
+		if reason == "" {
+			if t.throttled != "" {
+				printf("throttle released\n")
+			}
+
+			t.throttled = ""
+
+			return nil
+		}
+
+		if reason != t.throttled {
+			printf("throttled: %s\n", reason)
+		}
+
+		t.throttled = reason
+
+		if err := sleep(ctx, t.cfg.Interval); err != nil {
+			return err
+		}
+	}
+}
+
+// check returns the reason to throttle, empty means healthy.
+// A failed check throttles as well, since the health is unknown.
+func (t *Throttler) check(ctx context.Context) string {
+	if t.cfg.MaxThreadsRunning > 0 || len(t.cfg.Status) > 0 {
+		status, err := t.globalStatus(ctx)
+		if err != nil {
+			return fmt.Sprintf("failed to read global status: %s", err)
+		}
+
+		reason, err := CheckStatus(t.cfg, status)
+		if err != nil {
+			return err.Error()
+		}
+
+		if reason != "" {
+			return reason
+		}
+	}
+
+	for _, r := range t.replicas {
+		lag, err := replicaLag(ctx, r.db)
+		if err != nil {
+			return fmt.Sprintf("failed to read lag of replica %s: %s", r.addr, err)
+		}
+
+		if reason := CheckLag(t.cfg, r.addr, lag); reason != "" {
+			return reason
+		}
+	}
+
+	return ""
+}
+
+func (t *Throttler) globalStatus(ctx context.Context) (map[string]string, error) {
+	rows, err := t.db.QueryContext(ctx, "SHOW GLOBAL STATUS")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	status := make(map[string]string)
+
+	for rows.Next() {
+		var name, value string
+		if err := rows.Scan(&name, &value); err != nil {
+			return nil, err
+		}
+
+		status[strings.ToLower(name)] = value
+	}
+
+	return status, rows.Err()
+}
+
+// statusThresholds returns the thresholds of global status, including Threads_running.
+func statusThresholds(cfg *config.Throttle) []*config.StatusThreshold {
+	if cfg.MaxThreadsRunning == 0 {
+		return cfg.Status
+	}
+
+	return append([]*config.StatusThreshold{{Name: "Threads_running", Max: float64(cfg.MaxThreadsRunning)}}, cfg.Status...)
+}
+
+// ValidateStatus returns an error when global status keyed by lower-cased names lacks any of the configured ones,
+// or they are not numeric.
+func ValidateStatus(cfg *config.Throttle, status map[string]string) error {
+	for _, threshold := range statusThresholds(cfg) {
+		v, ok := status[strings.ToLower(threshold.Name)]
+		if !ok {
+			return fmt.Errorf("status %s is not found", threshold.Name)
+		}
+
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			return fmt.Errorf("status %s is not numeric: %s", threshold.Name, v)
+		}
+	}
+
+	return nil
+}
+
+// CheckStatus returns the reason to throttle by global status keyed by lower-cased names, empty means healthy.
+func CheckStatus(cfg *config.Throttle, status map[string]string) (string, error) {
+	if err := ValidateStatus(cfg, status); err != nil {
+		return "", err
+	}
+
+	for _, threshold := range statusThresholds(cfg) {
+		v := status[strings.ToLower(threshold.Name)]
+
+		// already validated
+		value, _ := strconv.ParseFloat(v, 64)
+		if value > threshold.Max {
+			return fmt.Sprintf("%s %s exceeds %s", threshold.Name, v, strconv.FormatFloat(threshold.Max, 'f', -1, 64)), nil
+		}
+	}
+
+	return "", nil
+}
+
+// CheckLag returns the reason to throttle by lag of the replica, nil lag means replication is stopped.
+func CheckLag(cfg *config.Throttle, addr string, lag *time.Duration) string {
+	if lag == nil {
+		return fmt.Sprintf("replication of replica %s is stopped", addr)
+	}
+
+	if *lag > cfg.MaxLag {
+		return fmt.Sprintf("lag of replica %s %s exceeds %s", addr, *lag, cfg.MaxLag)
+	}
+
+	return ""
+}
+
+// erParseError is the MySQL error number of syntax errors.
+const erParseError = 1064
+
+// replicaLag reads Seconds_Behind_Source of the replica, nil means replication is stopped.
+func replicaLag(ctx context.Context, db *sqlx.DB) (*time.Duration, error) {
+	// SHOW REPLICA STATUS is a syntax error before MySQL 8.0.22
+	rows, err := db.QueryxContext(ctx, "SHOW REPLICA STATUS")
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == erParseError {
+		rows, err = db.QueryxContext(ctx, "SHOW SLAVE STATUS")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+
+		return nil, errors.New("server is not a replica")
+	}
+
+	status := make(map[string]interface{})
+	if err := rows.MapScan(status); err != nil {
+		return nil, err
+	}
+
+	for _, column := range []string{"Seconds_Behind_Source", "Seconds_Behind_Master"} {
+		v, ok := status[column]
+		if !ok {
+			continue
+		}
+
+		if v == nil {
+			return nil, nil
+		}
+
+		seconds, err := strconv.Atoi(fmt.Sprintf("%s", v))
+		if err != nil {
+			return nil, fmt.Errorf("%s is not numeric: %v", column, v)
+		}
+
+		lag := time.Duration(seconds) * time.Second
+
+		return &lag, nil
+	}
+
+	return nil, errors.New("seconds behind source is not found")
+}
@@ -0,0 +1,172 @@
+/*
+Package database ...
+
+Copyright © 2019 hajime-terasawa <terako.studio@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/terakoya76/populator/config"
+	"github.com/terakoya76/populator/database"
+)
+
+func Test_CheckStatus(t *testing.T) {
+	cases := []struct {
+		name   string
+		cfg    *config.Throttle
+		status map[string]string
+		result string
+		err    bool
+	}{
+		{
+			name:   "healthy",
+			cfg:    &config.Throttle{MaxThreadsRunning: 50},
+			status: map[string]string{"threads_running": "10"},
+			result: "",
+		},
+		{
+			name:   "threads running exceeded",
+			cfg:    &config.Throttle{MaxThreadsRunning: 50},
+			status: map[string]string{"threads_running": "51"},
+			result: "Threads_running 51 exceeds 50",
+		},
+		{
+			name: "custom status exceeded",
+			cfg: &config.Throttle{
+				MaxThreadsRunning: 50,
+				Status:            []*config.StatusThreshold{{Name: "Innodb_row_lock_current_waits", Max: 2.5}},
+			},
+			status: map[string]string{"threads_running": "10", "innodb_row_lock_current_waits": "3"},
+			result: "Innodb_row_lock_current_waits 3 exceeds 2.5",
+		},
+		{
+			name:   "status not found",
+			cfg:    &config.Throttle{Status: []*config.StatusThreshold{{Name: "unknown", Max: 1}}},
+			status: map[string]string{"threads_running": "10"},
+			err:    true,
+		},
+		{
+			name:   "status not numeric",
+			cfg:    &config.Throttle{Status: []*config.StatusThreshold{{Name: "Rpl_status", Max: 1}}},
+			status: map[string]string{"rpl_status": "AUTH_MASTER"},
+			err:    true,
+		},
+	}
+
+	for _, c := range cases {
+		result, err := database.CheckStatus(c.cfg, c.status)
+		if !assert.Equal(t, c.err, err != nil) || !assert.Equal(t, c.result, result) {
+			t.Errorf("case: %s is failed\n", c.name)
+		}
+	}
+}
+
+func Test_ValidateStatus(t *testing.T) {
+	cases := []struct {
+		name   string
+		cfg    *config.Throttle
+		status map[string]string
+		err    bool
+	}{
+		{
+			name:   "no thresholds",
+			cfg:    &config.Throttle{},
+			status: map[string]string{},
+		},
+		{
+			name: "found",
+			cfg: &config.Throttle{
+				MaxThreadsRunning: 50,
+				Status:            []*config.StatusThreshold{{Name: "Innodb_row_lock_current_waits", Max: 2}},
+			},
+			status: map[string]string{"threads_running": "10", "innodb_row_lock_current_waits": "3"},
+		},
+		{
+			name:   "misspelled status",
+			cfg:    &config.Throttle{Status: []*config.StatusThreshold{{Name: "Innodb_row_lock_waits_current", Max: 2}}},
+			status: map[string]string{"threads_running": "10", "innodb_row_lock_current_waits": "3"},
+			err:    true,
+		},
+		{
+			name:   "status not numeric",
+			cfg:    &config.Throttle{Status: []*config.StatusThreshold{{Name: "Rpl_status", Max: 1}}},
+			status: map[string]string{"rpl_status": "AUTH_MASTER"},
+			err:    true,
+		},
+	}
+
+	for _, c := range cases {
+		if !assert.Equal(t, c.err, database.ValidateStatus(c.cfg, c.status) != nil) {
+			t.Errorf("case: %s is failed\n", c.name)
+		}
+	}
+}
+
+func Test_CheckLag(t *testing.T) {
+	lag := func(d time.Duration) *time.Duration { return &d }
+
+	cases := []struct {
+		name   string
+		lag    *time.Duration
+		result string
+	}{
+		{
+			name:   "within max lag",
+			lag:    lag(time.Second),
+			result: "",
+		},
+		{
+			name:   "max lag exceeded",
+			lag:    lag(3 * time.Second),
+			result: "lag of replica replica:3306 3s exceeds 1s",
+		},
+		{
+			name:   "replication stopped",
+			lag:    nil,
+			result: "replication of replica replica:3306 is stopped",
+		},
+	}
+
+	cfg := &config.Throttle{MaxLag: time.Second}
+
+	for _, c := range cases {
+		if !assert.Equal(t, c.result, database.CheckLag(cfg, "replica:3306", c.lag)) {
+			t.Errorf("case: %s is failed\n", c.name)
+		}
+	}
+}
+
+func Test_NewThrottler(t *testing.T) {
+	throttler, err := database.NewThrottler(context.Background(), nil, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, throttler)
+
+	// nil throttler never blocks
+	assert.NoError(t, throttler.Wait(context.Background()))
+	throttler.Close()
+
+	_, err = database.NewThrottler(context.Background(), nil, &config.Throttle{Replicas: []string{"root@invalid"}})
+	assert.Error(t, err)
+
+	// unreachable replica fails at first instead of throttling on every check
+	_, err = database.NewThrottler(context.Background(), nil, &config.Throttle{Replicas: []string{"root@tcp(127.0.0.1:1)/?timeout=1s"}})
+	assert.Error(t, err)
+}
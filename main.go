@@ -16,6 +16,8 @@ limitations under the License.
 package main
 
 import (
+	"time"
+
 	"github.com/spf13/cobra"
 
 	"github.com/terakoya76/populator/cmd"
@@ -30,6 +32,12 @@ func main() {
 	// will be global for your application.
 	cmd.RootCmd.PersistentFlags().StringVarP(&cmd.CfgFile, "config", "c", "", "config file (default is ./populator.yaml)")
 	cmd.RootCmd.PersistentFlags().BoolVarP(&cmd.ReCreate, "recreate", "r", false, "drop tables then create them from scratch")
+	cmd.RootCmd.PersistentFlags().BoolVarP(&cmd.Migrate, "migrate", "m", false, "alter existing tables to follow the config")
+	cmd.RootCmd.PersistentFlags().BoolVar(&cmd.Apply, "apply", false, "apply migration w/o confirmation")
+	cmd.RootCmd.PersistentFlags().Float64Var(&cmd.Rate, "rate", 0, "limit rows inserted per second across tables (default unlimited)")
+	cmd.RootCmd.PersistentFlags().Float64Var(&cmd.QPS, "qps", 0, "limit statements executed per second across tables (default unlimited)")
+	cmd.RootCmd.PersistentFlags().StringVar(&database.Progress, "progress", database.ProgressAuto, "show progress as auto, bar, log or none")
+	cmd.RootCmd.PersistentFlags().DurationVar(&database.ProgressInterval, "progress-interval", 10*time.Second, "interval of progress logs")
 	cmd.RootCmd.PersistentFlags().BoolVarP(&database.Verbose, "verbose", "v", false, "show executed sql")
 	cmd.RootCmd.DisableSuggestions = true
 
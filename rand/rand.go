@@ -20,8 +20,11 @@ package rand
 import (
 	"fmt"
 	"math"
+	mrand "math/rand"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/brianvoe/gofakeit/v7"
 )
@@ -266,110 +269,333 @@ func Year2() string {
 	return fmt.Sprint(gofakeit.Number(minY, maxY))
 }
 
-// Char returns random char with the given length.
-func Char(length int) string {
-	if length < 0 {
-		return ""
-	}
+// Alphabets of generated strings.
+const (
+	ASCII  = "ascii"
+	Latin1 = "latin1"
+	CJK    = "cjk"
+	Emoji  = "emoji"
+	Mixed  = "mixed"
+)
+
+// Byte length limits of text types.
+const (
+	tinyTextBytes   = 255
+	textBytes       = 65535
+	mediumTextBytes = 16777215
+)
+
+type runeRange struct {
+	lo rune
+	hi rune
+}
 
-	return gofakeit.LetterN(uint(length))
+var alphabetRanges = map[string][]runeRange{
+	Latin1: {{'A', 'Z'}, {'a', 'z'}, {0xC0, 0xD6}, {0xD8, 0xF6}, {0xF8, 0xFF}},
+	// Hiragana, Katakana, CJK Unified Ideographs
+	CJK: {{0x3041, 0x3096}, {0x30A1, 0x30FA}, {0x4E00, 0x9FFF}},
+	// Miscellaneous Symbols and Pictographs, Emoticons, Transport and Map Symbols
+	Emoji: {{0x1F300, 0x1F5FF}, {0x1F600, 0x1F64F}, {0x1F680, 0x1F6FF}},
 }
 
-// VarChar returns random varchar with the given length.
-func VarChar(length int) string {
+var mixedAlphabets = []string{ASCII, Latin1, CJK, Emoji}
+
+// String returns random string w/ the given length in characters of the given alphabet.
+// When maxBytes is not negative, characters are appended as long as utf-8 byte length fits in it.
+func String(length, maxBytes int, alphabet string) string {
 	if length < 0 {
 		return ""
 	}
 
-	return gofakeit.LetterN(uint(length))
-}
+	if alphabet == "" || alphabet == ASCII {
+		if maxBytes >= 0 && length > maxBytes {
+			length = maxBytes
+		}
 
-// Binary returns random binary with the given length.
-func Binary(length int) string {
-	if length < 0 {
-		return ""
+		return gofakeit.LetterN(uint(length))
+	}
+
+	var (
+		sb    strings.Builder
+		bytes int
+	)
+
+	for i := 0; i < length; i++ {
+		r := randomRune(alphabet)
+
+		n := utf8.RuneLen(r)
+		if maxBytes >= 0 && bytes+n > maxBytes {
+			break
+		}
+
+		sb.WriteRune(r)
+		bytes += n
 	}
 
-	return gofakeit.LetterN(uint(length))
+	return sb.String()
 }
 
-// VarBinary returns random varbinary with the given length.
-func VarBinary(length int) string {
-	if length < 0 {
-		return ""
+func randomRune(alphabet string) rune {
+	if alphabet == Mixed {
+		alphabet = mixedAlphabets[gofakeit.Number(0, len(mixedAlphabets)-1)]
 	}
 
-	return gofakeit.LetterN(uint(length))
+	ranges, ok := alphabetRanges[alphabet]
+	if !ok {
+		return rune(gofakeit.Letter()[0])
+	}
+
+	rr := ranges[gofakeit.Number(0, len(ranges)-1)]
+
+	return rune(gofakeit.Number(int(rr.lo), int(rr.hi)))
 }
 
-// TinyBlob returns random tiny blob with the given length.
-func TinyBlob(length int) string {
+// Char returns random char with the given length in characters of the given alphabet.
+func Char(length int, alphabet string) string {
+	return String(length, -1, alphabet)
+}
+
+// VarChar returns random varchar with the given length in characters of the given alphabet.
+func VarChar(length int, alphabet string) string {
+	return String(length, -1, alphabet)
+}
+
+// Bytes returns random bytes with the given length.
+// entropy is the ratio of random bytes, and the rest repeats the previous byte, so the lower it is, the more compressible.
+func Bytes(length int, entropy float64) []byte {
 	if length < 0 {
-		return ""
+		return []byte{}
+	}
+
+	b := make([]byte, length)
+	for i := range b {
+		if i == 0 || gofakeit.Float64Range(0, 1) < entropy {
+			b[i] = gofakeit.Uint8()
+			continue
+		}
+
+		b[i] = b[i-1]
 	}
 
-	return gofakeit.LetterN(uint(length))
+	return b
 }
 
-// TinyText returns random tiny text with the given length.
-func TinyText(length int) string {
-	if length < 0 {
-		return ""
+// Binary returns random binary with the given length and entropy.
+func Binary(length int, entropy float64) []byte {
+	return Bytes(length, entropy)
+}
+
+// VarBinary returns random varbinary with the given length and entropy.
+func VarBinary(length int, entropy float64) []byte {
+	return Bytes(length, entropy)
+}
+
+// TinyBlob returns random tiny blob with the given length and entropy.
+func TinyBlob(length int, entropy float64) []byte {
+	return Bytes(length, entropy)
+}
+
+// TinyText returns random tiny text with the given length in characters of the given alphabet.
+func TinyText(length int, alphabet string) string {
+	return String(length, tinyTextBytes, alphabet)
+}
+
+// Blob returns random blob with the given length and entropy.
+func Blob(length int, entropy float64) []byte {
+	return Bytes(length, entropy)
+}
+
+// Text returns random text with the given length in characters of the given alphabet.
+func Text(length int, alphabet string) string {
+	return String(length, textBytes, alphabet)
+}
+
+// MediumBlob returns random medium blob with the given length and entropy.
+func MediumBlob(length int, entropy float64) []byte {
+	return Bytes(length, entropy)
+}
+
+// MediumText returns random medium text with the given length in characters of the given alphabet.
+func MediumText(length int, alphabet string) string {
+	return String(length, mediumTextBytes, alphabet)
+}
+
+// LongBlob returns random long blob with the given length and entropy.
+func LongBlob(length int, entropy float64) []byte {
+	return Bytes(length, entropy)
+}
+
+// LongText returns random long text with the given length in characters of the given alphabet.
+func LongText(length int, alphabet string) string {
+	return String(length, -1, alphabet)
+}
+
+// Set returns random subset of the given members joined by comma.
+func Set(members []string) string {
+	subset := make([]string, 0, len(members))
+	for _, m := range members {
+		if Boolean() {
+			subset = append(subset, m)
+		}
 	}
 
-	return gofakeit.LetterN(uint(length))
+	return strings.Join(subset, ",")
 }
 
-// Blob returns random blob with the given length.
-func Blob(length int) string {
-	if length < 0 {
-		return ""
+// Point returns random WKT point inside the given bounding box.
+func Point(minX, minY, maxX, maxY float64) string {
+	return fmt.Sprintf("POINT(%s)", coordinate(minX, minY, maxX, maxY))
+}
+
+// LineString returns random WKT linestring inside the given bounding box.
+func LineString(minX, minY, maxX, maxY float64) string {
+	//nolint:mnd
+	n := gofakeit.Number(2, 5)
+
+	coords := make([]string, n)
+	for i := range coords {
+		coords[i] = coordinate(minX, minY, maxX, maxY)
 	}
 
-	return gofakeit.LetterN(uint(length))
+	return fmt.Sprintf("LINESTRING(%s)", strings.Join(coords, ", "))
 }
 
-// Text returns random text with the given length.
-func Text(length int) string {
-	if length < 0 {
-		return ""
+// Polygon returns random WKT polygon inside the given bounding box.
+// Vertices are placed around a center in counterclockwise order, so the ring never intersects itself.
+func Polygon(minX, minY, maxX, maxY float64) string {
+	//nolint:mnd
+	var (
+		n       = gofakeit.Number(3, 8)
+		radius  = math.Min(maxX-minX, maxY-minY) / 20
+		centerX = gofakeit.Float64Range(minX+radius, maxX-radius)
+		centerY = gofakeit.Float64Range(minY+radius, maxY-radius)
+	)
+
+	angles := make([]float64, n)
+	for i := range angles {
+		angles[i] = 2 * math.Pi * (float64(i) + gofakeit.Float64Range(0, 0.9)) / float64(n)
+	}
+
+	coords := make([]string, 0, n+1)
+	for _, a := range angles {
+		r := radius * gofakeit.Float64Range(0.3, 1)
+		coords = append(coords, fmt.Sprintf("%.6f %.6f", centerX+r*math.Cos(a), centerY+r*math.Sin(a)))
 	}
 
-	return gofakeit.LetterN(uint(length))
+	// close the ring
+	coords = append(coords, coords[0])
+
+	return fmt.Sprintf("POLYGON((%s))", strings.Join(coords, ", "))
 }
 
-// MediumBlob returns random medium blob with the given length.
-func MediumBlob(length int) string {
-	if length < 0 {
-		return ""
+// Geometry returns random WKT point, linestring or polygon inside the given bounding box.
+func Geometry(minX, minY, maxX, maxY float64) string {
+	//nolint:mnd
+	switch gofakeit.Number(0, 2) {
+	case 0:
+		return Point(minX, minY, maxX, maxY)
+	case 1:
+		return LineString(minX, minY, maxX, maxY)
+	default:
+		return Polygon(minX, minY, maxX, maxY)
 	}
+}
 
-	return gofakeit.LetterN(uint(length))
+func coordinate(minX, minY, maxX, maxY float64) string {
+	return fmt.Sprintf("%.6f %.6f", gofakeit.Float64Range(minX, maxX), gofakeit.Float64Range(minY, maxY))
 }
 
-// MediumText returns random medium text with the given length.
-func MediumText(length int) string {
-	if length < 0 {
-		return ""
+// IntRange returns random integer between min and max inclusive.
+func IntRange(minI, maxI int64) int64 {
+	if minI >= maxI {
+		return minI
+	}
+
+	span := uint64(maxI - minI)
+	if span == math.MaxUint64 {
+		return int64(gofakeit.Uint64())
 	}
 
-	return gofakeit.LetterN(uint(length))
+	return minI + int64(gofakeit.UintRange(0, uint(span)))
 }
 
-// LongBlob returns random long blob with the given length.
-func LongBlob(length int) string {
-	if length < 0 {
-		return ""
+// Words generates text of words drawn from a vocabulary w/ Zipfian frequencies,
+// i.e. the k-th word in the vocabulary appears proportionally to 1/k^s.
+type Words struct {
+	vocabulary []string
+
+	mu   sync.Mutex
+	zipf *mrand.Zipf
+}
+
+// NewWords builds Words. skew is the exponent s of Zipf's law, and must be greater than 1.
+func NewWords(vocabulary []string, skew float64) *Words {
+	//nolint:gosec
+	r := mrand.New(mrand.NewSource(time.Now().UnixNano()))
+
+	return &Words{
+		vocabulary: vocabulary,
+		zipf:       mrand.NewZipf(r, skew, 1, uint64(len(vocabulary)-1)),
+	}
+}
+
+// Vocabulary returns n distinct words from the dictionary, fewer if the dictionary runs out.
+func Vocabulary(n int) []string {
+	seen := make(map[string]bool, n)
+	words := make([]string, 0, n)
+
+	// give up after enough misses, the dictionary is finite
+	for misses := 0; len(words) < n && misses < n*10+100; {
+		w := strings.ToLower(gofakeit.Word())
+		if seen[w] {
+			misses++
+			continue
+		}
+
+		seen[w] = true
+		words = append(words, w)
 	}
 
-	return gofakeit.LetterN(uint(length))
+	return words
 }
 
-// LongText returns random long text with the given length.
-func LongText(length int) string {
-	if length < 0 {
-		return ""
+// Word returns a single word.
+func (w *Words) Word() string {
+	w.mu.Lock()
+	k := w.zipf.Uint64()
+	w.mu.Unlock()
+
+	return w.vocabulary[k]
+}
+
+// Text returns text of words between minWords and maxWords, cut off at maxChars characters.
+func (w *Words) Text(minWords, maxWords, maxChars int) string {
+	n := gofakeit.Number(minWords, maxWords)
+
+	var (
+		sb    strings.Builder
+		chars int
+	)
+
+	for i := 0; i < n; i++ {
+		word := w.Word()
+
+		length := utf8.RuneCountInString(word)
+		if i > 0 {
+			length++
+		}
+
+		if maxChars >= 0 && chars+length > maxChars {
+			break
+		}
+
+		if i > 0 {
+			sb.WriteString(" ")
+		}
+
+		sb.WriteString(word)
+		chars += length
 	}
 
-	return gofakeit.LetterN(uint(length))
+	return sb.String()
 }
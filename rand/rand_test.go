@@ -0,0 +1,174 @@
+/*
+Package rand ...
+
+Copyright © 2019 hajime-terasawa <terako.studio@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package rand_test
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/terakoya76/populator/rand"
+)
+
+func Test_String(t *testing.T) {
+	cases := []struct {
+		name     string
+		length   int
+		maxBytes int
+		alphabet string
+		minRunes int
+		maxRunes int
+		runeLen  int
+	}{
+		{
+			name:     "ascii",
+			length:   10,
+			maxBytes: -1,
+			alphabet: rand.ASCII,
+			minRunes: 10,
+			maxRunes: 10,
+			runeLen:  1,
+		},
+
+		{
+			name:     "emoji",
+			length:   10,
+			maxBytes: -1,
+			alphabet: rand.Emoji,
+			minRunes: 10,
+			maxRunes: 10,
+			runeLen:  4,
+		},
+
+		{
+			name:     "cjk w/ byte limit",
+			length:   255,
+			maxBytes: 255,
+			alphabet: rand.CJK,
+			minRunes: 85,
+			maxRunes: 85,
+			runeLen:  3,
+		},
+
+		{
+			name:     "mixed w/ byte limit",
+			length:   255,
+			maxBytes: 255,
+			alphabet: rand.Mixed,
+			minRunes: 1,
+			maxRunes: 255,
+			runeLen:  0,
+		},
+	}
+
+	for _, c := range cases {
+		s := rand.String(c.length, c.maxBytes, c.alphabet)
+		runes := utf8.RuneCountInString(s)
+
+		if !utf8.ValidString(s) {
+			t.Errorf("case: %s is failed, invalid utf-8: %q\n", c.name, s)
+		}
+
+		if !assert.True(t, runes >= c.minRunes && runes <= c.maxRunes) {
+			t.Errorf("case: %s is failed, expected: %d-%d runes, actual: %d\n", c.name, c.minRunes, c.maxRunes, runes)
+		}
+
+		if c.maxBytes >= 0 && !assert.LessOrEqual(t, len(s), c.maxBytes) {
+			t.Errorf("case: %s is failed, expected: <= %d bytes, actual: %d\n", c.name, c.maxBytes, len(s))
+		}
+
+		if c.runeLen > 0 && !assert.Equal(t, runes*c.runeLen, len(s)) {
+			t.Errorf("case: %s is failed, expected: %d bytes per rune, actual: %q\n", c.name, c.runeLen, s)
+		}
+	}
+}
+
+func Test_Words(t *testing.T) {
+	vocabulary := []string{"the", "of", "and", "populator", "zipf"}
+	words := rand.NewWords(vocabulary, 1.5)
+
+	counts := make(map[string]int)
+	for i := 0; i < 10000; i++ {
+		counts[words.Word()]++
+	}
+
+	// the more frequent rank, the more appearances
+	for i := 1; i < len(vocabulary); i++ {
+		assert.Greater(t, counts[vocabulary[i-1]], counts[vocabulary[i]], "rank %d and %d", i-1, i)
+	}
+
+	for i := 0; i < 100; i++ {
+		text := words.Text(3, 10, 20)
+		assert.LessOrEqual(t, utf8.RuneCountInString(text), 20, "text: %q", text)
+	}
+}
+
+func Test_Bytes(t *testing.T) {
+	cases := []struct {
+		name     string
+		length   int
+		entropy  float64
+		minBytes int
+		maxBytes int
+	}{
+		{
+			name:     "negative length",
+			length:   -1,
+			entropy:  1,
+			minBytes: 0,
+			maxBytes: 0,
+		},
+		{
+			name:     "empty",
+			length:   0,
+			entropy:  1,
+			minBytes: 0,
+			maxBytes: 0,
+		},
+		{
+			name:     "no entropy repeats the first byte",
+			length:   1000,
+			entropy:  0,
+			minBytes: 1,
+			maxBytes: 1,
+		},
+		{
+			name:     "full entropy",
+			length:   1000,
+			entropy:  1,
+			minBytes: 200,
+			maxBytes: 256,
+		},
+	}
+
+	for _, c := range cases {
+		b := rand.Bytes(c.length, c.entropy)
+
+		distinct := make(map[byte]bool)
+		for _, v := range b {
+			distinct[v] = true
+		}
+
+		if !assert.Len(t, b, max(c.length, 0)) ||
+			!assert.GreaterOrEqual(t, len(distinct), c.minBytes) ||
+			!assert.LessOrEqual(t, len(distinct), c.maxBytes) {
+			t.Errorf("case: %s is failed\n", c.name)
+		}
+	}
+}